@@ -7,5 +7,25 @@ type Algorithm string
 // The supported signature types.
 const (
 	None  Algorithm = "None"
+	HS256 Algorithm = "HS256"
 	ES256 Algorithm = "ES256"
+	RS256 Algorithm = "RS256"
 )
+
+// Strength ranks the algorithm by the assurance its signatures provide, so that
+// minimum-strength policies can be enforced.  Higher values are stronger.  None
+// and unrecognized algorithms have a strength of 0.  Symmetric algorithms rank
+// below asymmetric ones, as anyone able to verify their signatures can also
+// forge them.
+func (a Algorithm) Strength() int {
+	switch a {
+	case HS256:
+		return 1
+	case RS256:
+		return 2
+	case ES256:
+		return 3
+	}
+
+	return 0
+}
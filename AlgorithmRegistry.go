@@ -0,0 +1,151 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"sync"
+)
+
+// ErrBuiltInAlgorithm is returned when attempting to register an algorithm that
+// is built in to the package.
+var ErrBuiltInAlgorithm = errors.New("built-in algorithms cannot be redefined")
+
+// ErrInvalidKeyType is returned when a key of the wrong type is provided for an
+// algorithm.
+var ErrInvalidKeyType = errors.New("the key is of the wrong type for the algorithm")
+
+// AlgorithmFactory creates the signers and verifiers for a custom algorithm from
+// key material.  The type of the key is defined by the algorithm.
+type AlgorithmFactory struct {
+	NewSigner   func(key interface{}) (Signer, error)
+	NewVerifier func(key interface{}) (Verifier, error)
+}
+
+var algorithmFactories = struct {
+	sync.RWMutex
+	m map[Algorithm]AlgorithmFactory
+}{m: map[Algorithm]AlgorithmFactory{}}
+
+// RegisterAlgorithm registers the factory to use for a custom algorithm,
+// replacing any factory previously registered for it.  Once registered, the
+// algorithm is recognized by NewSignerFor, NewVerifierFor and
+// VerifierSet.RegisterKey.  Built-in algorithms cannot be redefined.
+func RegisterAlgorithm(alg Algorithm, factory AlgorithmFactory) error {
+	if isBuiltInAlgorithm(alg) {
+		return ErrBuiltInAlgorithm
+	}
+
+	algorithmFactories.Lock()
+	defer algorithmFactories.Unlock()
+
+	algorithmFactories.m[alg] = factory
+	return nil
+}
+
+// UnregisterAlgorithm removes the factory registered for a custom algorithm, if
+// any.
+func UnregisterAlgorithm(alg Algorithm) {
+	algorithmFactories.Lock()
+	defer algorithmFactories.Unlock()
+
+	delete(algorithmFactories.m, alg)
+}
+
+// NewSignerFor creates a Signer for the provided algorithm and key.  HS256
+// expects a []byte secret, ES256 an *ecdsa.PrivateKey and RS256 an
+// *rsa.PrivateKey.  Custom algorithms are created by their registered factory.
+func NewSignerFor(alg Algorithm, key interface{}) (Signer, error) {
+	switch alg {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, ErrInvalidKeyType
+		}
+
+		return NewHS256Signer(secret), nil
+	case ES256:
+		privateKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, ErrInvalidKeyType
+		}
+
+		return NewES256Signer(privateKey), nil
+	case RS256:
+		privateKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, ErrInvalidKeyType
+		}
+
+		return NewRS256Signer(privateKey), nil
+	}
+
+	factory, ok := getAlgorithmFactory(alg)
+	if !ok || factory.NewSigner == nil {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	return factory.NewSigner(key)
+}
+
+// NewVerifierFor creates a Verifier for the provided algorithm and key.  HS256
+// expects a []byte secret, ES256 an *ecdsa.PublicKey and RS256 an
+// *rsa.PublicKey.  Custom algorithms are created by their registered factory.
+func NewVerifierFor(alg Algorithm, key interface{}) (Verifier, error) {
+	switch alg {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, ErrInvalidKeyType
+		}
+
+		return NewHS256Verifier(secret), nil
+	case ES256:
+		publicKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, ErrInvalidKeyType
+		}
+
+		return NewES256Verifier(publicKey), nil
+	case RS256:
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, ErrInvalidKeyType
+		}
+
+		return NewRS256Verifier(publicKey), nil
+	}
+
+	factory, ok := getAlgorithmFactory(alg)
+	if !ok || factory.NewVerifier == nil {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	return factory.NewVerifier(key)
+}
+
+func getAlgorithmFactory(alg Algorithm) (AlgorithmFactory, bool) {
+	algorithmFactories.RLock()
+	defer algorithmFactories.RUnlock()
+
+	factory, ok := algorithmFactories.m[alg]
+	return factory, ok
+}
+
+func isBuiltInAlgorithm(alg Algorithm) bool {
+	switch alg {
+	case None, HS256, ES256, RS256:
+		return true
+	}
+
+	return false
+}
+
+func isRecognizedAlgorithm(alg Algorithm) bool {
+	if isBuiltInAlgorithm(alg) {
+		return true
+	}
+
+	_, ok := getAlgorithmFactory(alg)
+	return ok
+}
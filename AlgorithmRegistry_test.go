@@ -0,0 +1,151 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+const prefixedHS256 Algorithm = "XHS256"
+
+type prefixedHS256Signer struct {
+	secret []byte
+}
+
+func (s *prefixedHS256Signer) Algorithm() Algorithm {
+	return prefixedHS256
+}
+
+func (s *prefixedHS256Signer) Sign(b64HeaderAndBody string) ([]byte, error) {
+	return prefixedHS256MAC(s.secret, b64HeaderAndBody), nil
+}
+
+type prefixedHS256Verifier struct {
+	secret []byte
+}
+
+func (v *prefixedHS256Verifier) Verify(b64HeaderAndBody string, signature []byte) bool {
+	return hmac.Equal(prefixedHS256MAC(v.secret, b64HeaderAndBody), signature)
+}
+
+func prefixedHS256MAC(secret []byte, b64HeaderAndBody string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("test-domain:"))
+	mac.Write([]byte(b64HeaderAndBody))
+
+	return mac.Sum(nil)
+}
+
+func registerPrefixedHS256(t *testing.T) {
+	err := RegisterAlgorithm(prefixedHS256, AlgorithmFactory{
+		NewSigner: func(key interface{}) (Signer, error) {
+			secret, ok := key.([]byte)
+			if !ok {
+				return nil, ErrInvalidKeyType
+			}
+
+			return &prefixedHS256Signer{secret: secret}, nil
+		},
+		NewVerifier: func(key interface{}) (Verifier, error) {
+			secret, ok := key.([]byte)
+			if !ok {
+				return nil, ErrInvalidKeyType
+			}
+
+			return &prefixedHS256Verifier{secret: secret}, nil
+		},
+	})
+
+	test.That(t, err).IsNil()
+}
+
+func TestRegisterAlgorithmRoundTrip(t *testing.T) {
+	// Arrange.
+	registerPrefixedHS256(t)
+	defer UnregisterAlgorithm(prefixedHS256)
+	secret := []byte("a-secret-for-the-custom-algorithm")
+
+	signer, err := NewSignerFor(prefixedHS256, secret)
+	test.That(t, err).IsNil()
+
+	tokenString := signedTokenString(t, signer, func(token *Token) {
+		token.AddClaim("sub", "user-1")
+	})
+
+	set := NewVerifierSet()
+	err = set.RegisterKey(prefixedHS256, secret)
+	test.That(t, err).IsNil()
+
+	// Act.
+	parsed, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+	err = set.Verify(parsed)
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, parsed.Header.Algorithm).IsEqualTo(prefixedHS256)
+	test.That(t, parsed.Verify(NewHS256Verifier(secret))).IsFalse()
+}
+
+func TestRegisterAlgorithmRejectsBuiltIns(t *testing.T) {
+	for _, alg := range []Algorithm{None, HS256, ES256, RS256} {
+		// Act.
+		err := RegisterAlgorithm(alg, AlgorithmFactory{})
+
+		// Assert.
+		test.That(t, err).IsEqualTo(ErrBuiltInAlgorithm)
+	}
+}
+
+func TestNewSignerForBuiltInAlgorithms(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	// Act.
+	signer, err := NewSignerFor(ES256, privateKey)
+	test.That(t, err).IsNil()
+	verifier, err := NewVerifierFor(ES256, &privateKey.PublicKey)
+	test.That(t, err).IsNil()
+
+	token := NewToken()
+	test.That(t, token.Sign(signer)).IsNil()
+
+	// Assert.
+	test.That(t, token.Verify(verifier)).IsTrue()
+}
+
+func TestNewSignerForRejectsWrongKeyType(t *testing.T) {
+	// Act.
+	_, signerErr := NewSignerFor(ES256, []byte("secret"))
+	_, verifierErr := NewVerifierFor(RS256, []byte("secret"))
+
+	// Assert.
+	test.That(t, signerErr).IsEqualTo(ErrInvalidKeyType)
+	test.That(t, verifierErr).IsEqualTo(ErrInvalidKeyType)
+}
+
+func TestNewVerifierForRejectsUnknownAlgorithm(t *testing.T) {
+	// Act.
+	_, err := NewVerifierFor(Algorithm("XX999"), []byte("secret"))
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrUnsupportedAlgorithm)
+}
+
+func TestUnregisterAlgorithm(t *testing.T) {
+	// Arrange.
+	registerPrefixedHS256(t)
+
+	// Act.
+	UnregisterAlgorithm(prefixedHS256)
+	_, err := NewVerifierFor(prefixedHS256, []byte("secret"))
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrUnsupportedAlgorithm)
+}
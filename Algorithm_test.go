@@ -0,0 +1,23 @@
+package jwt
+
+import (
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestAlgorithmStrength(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.Header.Algorithm = ES256
+
+	// Act.
+	strength := token.AlgorithmStrength()
+
+	// Assert.
+	test.That(t, strength).IsGreaterThan(None.Strength())
+	test.That(t, strength).IsGreaterThan(HS256.Strength())
+	test.That(t, strength).IsGreaterThan(RS256.Strength())
+	test.That(t, HS256.Strength()).IsGreaterThan(None.Strength())
+	test.That(t, Algorithm("XX999").Strength()).IsEqualTo(0)
+}
@@ -0,0 +1,100 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+)
+
+// AuthorizeOptions describes the requirements that Authorize checks a token
+// against.  Zero values disable the corresponding check, except for Now, which
+// defaults to the current time.
+type AuthorizeOptions struct {
+	AllowedAlgorithms []Algorithm
+	Issuer            string
+	Audience          string
+	RequiredScopes    []string
+	Skew              time.Duration
+	Now               time.Time
+}
+
+// Authorize parses the provided string token, verifies its signature with the
+// provided verifier and checks it against the provided options.  The algorithm
+// is checked before the signature, followed by the exp and nbf claims (allowing
+// for Skew), the issuer, the audience and finally the required scopes.  The
+// first failure is returned.
+func Authorize(tokenString string, verifier Verifier, opts AuthorizeOptions) (*Token, error) {
+	token, _, err := AuthorizeWithReason(tokenString, verifier, opts)
+	return token, err
+}
+
+// AuthorizeWithReason is the same as Authorize, but also returns the
+// FailureReason categorizing any failure.  The reason is ReasonNone when the
+// returned error is nil.
+func AuthorizeWithReason(tokenString string, verifier Verifier, opts AuthorizeOptions) (*Token, FailureReason, error) {
+	token, err := Parse(tokenString)
+	if err != nil {
+		return nil, ReasonMalformed, err
+	}
+
+	if len(opts.AllowedAlgorithms) > 0 && !algorithmAllowed(token.Header.Algorithm, opts.AllowedAlgorithms) {
+		return nil, ReasonWrongAlgorithm, ErrUnsupportedAlgorithm
+	}
+
+	if !token.Verify(verifier) {
+		return nil, ReasonBadSignature, ErrInvalidSignature
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	validateOpts := []ValidateOption{
+		WithLeeway(opts.Skew),
+		WithClock(func() time.Time { return now }),
+	}
+
+	if opts.Issuer != "" {
+		validateOpts = append(validateOpts, WithExpectedIssuer(opts.Issuer))
+	}
+
+	if opts.Audience != "" {
+		validateOpts = append(validateOpts, WithExpectedAudience(opts.Audience))
+	}
+
+	err = token.Validate(validateOpts...)
+	if err != nil {
+		return nil, reasonForValidationError(err), err
+	}
+
+	if !token.HasAllScopes(opts.RequiredScopes...) {
+		return nil, ReasonInsufficientScope, ErrInsufficientScope
+	}
+
+	return token, ReasonNone, nil
+}
+
+func reasonForValidationError(err error) FailureReason {
+	switch {
+	case errors.Is(err, ErrInvalidIssuer):
+		return ReasonWrongIssuer
+	case errors.Is(err, ErrInvalidAudience):
+		return ReasonWrongAudience
+	case errors.Is(err, ErrExpired):
+		return ReasonExpired
+	case errors.Is(err, ErrNotYetValid):
+		return ReasonNotYetValid
+	}
+
+	return ReasonInvalidClaims
+}
+
+func algorithmAllowed(alg Algorithm, allowed []Algorithm) bool {
+	for _, v := range allowed {
+		if v == alg {
+			return true
+		}
+	}
+
+	return false
+}
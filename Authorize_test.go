@@ -0,0 +1,149 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestAuthorize(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Unix(1600000000, 0)
+	verifier := NewES256Verifier(&privateKey.PublicKey)
+
+	tokenString := authorizeTestToken(t, NewES256Signer(privateKey), now)
+	wrongKeyTokenString := authorizeTestToken(t, NewES256Signer(otherKey), now)
+
+	full := AuthorizeOptions{
+		AllowedAlgorithms: []Algorithm{ES256},
+		Issuer:            "Test Issuer",
+		Audience:          "api",
+		RequiredScopes:    []string{"user:read"},
+		Now:               now,
+	}
+
+	testCases := []struct {
+		name        string
+		tokenString string
+		mutate      func(opts *AuthorizeOptions)
+		expected    error
+	}{
+		{"valid", tokenString, func(opts *AuthorizeOptions) {}, nil},
+		{"no options", tokenString, func(opts *AuthorizeOptions) { *opts = AuthorizeOptions{Now: now} }, nil},
+		{"wrong key", wrongKeyTokenString, func(opts *AuthorizeOptions) {}, ErrInvalidSignature},
+		{"disallowed algorithm", tokenString, func(opts *AuthorizeOptions) { opts.AllowedAlgorithms = []Algorithm{RS256} }, ErrUnsupportedAlgorithm},
+		{"expired", tokenString, func(opts *AuthorizeOptions) { opts.Now = now.Add(2 * time.Hour) }, ErrExpired},
+		{"expired within skew", tokenString, func(opts *AuthorizeOptions) { opts.Now = now.Add(time.Hour); opts.Skew = time.Minute }, nil},
+		{"not yet valid", tokenString, func(opts *AuthorizeOptions) { opts.Now = now.Add(-time.Hour) }, ErrNotYetValid},
+		{"not yet valid within skew", tokenString, func(opts *AuthorizeOptions) { opts.Now = now.Add(-time.Second); opts.Skew = time.Minute }, nil},
+		{"wrong issuer", tokenString, func(opts *AuthorizeOptions) { opts.Issuer = "Other Issuer" }, ErrInvalidIssuer},
+		{"wrong audience", tokenString, func(opts *AuthorizeOptions) { opts.Audience = "cli" }, ErrInvalidAudience},
+		{"missing scope", tokenString, func(opts *AuthorizeOptions) { opts.RequiredScopes = []string{"user:read", "user:delete"} }, ErrInsufficientScope},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := full
+			testCase.mutate(&opts)
+
+			// Act.
+			token, err := Authorize(testCase.tokenString, verifier, opts)
+
+			// Assert.
+			test.That(t, err).IsEqualTo(testCase.expected)
+			test.That(t, token != nil).IsEqualTo(testCase.expected == nil)
+		})
+	}
+}
+
+func TestAuthorizeWithReason(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Unix(1600000000, 0)
+	verifier := NewES256Verifier(&privateKey.PublicKey)
+
+	tokenString := authorizeTestToken(t, NewES256Signer(privateKey), now)
+	wrongKeyTokenString := authorizeTestToken(t, NewES256Signer(otherKey), now)
+
+	full := AuthorizeOptions{
+		AllowedAlgorithms: []Algorithm{ES256},
+		Issuer:            "Test Issuer",
+		Audience:          "api",
+		RequiredScopes:    []string{"user:read"},
+		Now:               now,
+	}
+
+	testCases := []struct {
+		name        string
+		tokenString string
+		mutate      func(opts *AuthorizeOptions)
+		expected    FailureReason
+	}{
+		{"valid", tokenString, func(opts *AuthorizeOptions) {}, ReasonNone},
+		{"malformed", "not.a-token", func(opts *AuthorizeOptions) {}, ReasonMalformed},
+		{"wrong key", wrongKeyTokenString, func(opts *AuthorizeOptions) {}, ReasonBadSignature},
+		{"disallowed algorithm", tokenString, func(opts *AuthorizeOptions) { opts.AllowedAlgorithms = []Algorithm{RS256} }, ReasonWrongAlgorithm},
+		{"expired", tokenString, func(opts *AuthorizeOptions) { opts.Now = now.Add(2 * time.Hour) }, ReasonExpired},
+		{"not yet valid", tokenString, func(opts *AuthorizeOptions) { opts.Now = now.Add(-time.Hour) }, ReasonNotYetValid},
+		{"wrong issuer", tokenString, func(opts *AuthorizeOptions) { opts.Issuer = "Other Issuer" }, ReasonWrongIssuer},
+		{"wrong audience", tokenString, func(opts *AuthorizeOptions) { opts.Audience = "cli" }, ReasonWrongAudience},
+		{"missing scope", tokenString, func(opts *AuthorizeOptions) { opts.RequiredScopes = []string{"user:delete"} }, ReasonInsufficientScope},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := full
+			testCase.mutate(&opts)
+
+			// Act.
+			_, reason, err := AuthorizeWithReason(testCase.tokenString, verifier, opts)
+
+			// Assert.
+			test.That(t, reason).IsEqualTo(testCase.expected)
+			test.That(t, err == nil).IsEqualTo(testCase.expected == ReasonNone)
+		})
+	}
+}
+
+func TestAuthorizeRejectsMalformedExpiry(t *testing.T) {
+	// Arrange.
+	secret := []byte("secret")
+	tokenString := signedTokenString(t, NewHS256Signer(secret), func(token *Token) {
+		token.AddClaim("exp", "1")
+	})
+
+	// Act.
+	authorized, reason, err := AuthorizeWithReason(tokenString, NewHS256Verifier(secret), AuthorizeOptions{})
+
+	// Assert.
+	test.That(t, authorized).IsNil()
+	test.That(t, reason).IsEqualTo(ReasonInvalidClaims)
+	test.That(t, errors.Is(err, ErrInvalidTimeClaim)).IsTrue()
+}
+
+func authorizeTestToken(t *testing.T, signer Signer, now time.Time) string {
+	return signedTokenString(t, signer, func(token *Token) {
+		token.AddClaim("iss", "Test Issuer")
+		token.AddClaim("nbf", now.Unix())
+		token.SetAudience("api", "web")
+		token.SetExpiry(now.Add(time.Hour))
+		token.AddScope("user:read")
+		token.AddScope("user:create")
+	})
+}
@@ -0,0 +1,75 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrExpiryInPast is returned when a token is built with an expiry time that has
+// already passed.
+var ErrExpiryInPast = errors.New("the token expiry time is in the past")
+
+// Builder provides a fluent way of constructing and signing a token.
+type Builder struct {
+	token  *Token
+	expiry time.Time
+}
+
+// NewBuilder creates a new Builder for an empty, unsigned JWT.
+func NewBuilder() *Builder {
+	return &Builder{
+		token: NewToken(),
+	}
+}
+
+// Issuer sets the issuer of the token.
+func (b *Builder) Issuer(iss string) *Builder {
+	b.token.AddClaim("iss", iss)
+	return b
+}
+
+// Subject sets the subject of the token.
+func (b *Builder) Subject(sub string) *Builder {
+	b.token.AddClaim("sub", sub)
+	return b
+}
+
+// Audience sets the audience of the token.
+func (b *Builder) Audience(audience ...string) *Builder {
+	b.token.SetAudience(audience...)
+	return b
+}
+
+// Expiry sets the expiry time of the token.
+func (b *Builder) Expiry(exp time.Time) *Builder {
+	b.expiry = exp
+	b.token.SetExpiry(exp)
+	return b
+}
+
+// Scope adds a scope to the token.
+func (b *Builder) Scope(scope string) *Builder {
+	b.token.AddScope(scope)
+	return b
+}
+
+// Claim adds a claim to the token.
+func (b *Builder) Claim(name string, value interface{}) *Builder {
+	b.token.AddClaim(name, value)
+	return b
+}
+
+// Sign validates the token that has been built and signs it with the provided
+// Signer.
+func (b *Builder) Sign(signer Signer) (*Token, error) {
+	if !b.expiry.IsZero() && !b.expiry.After(time.Now()) {
+		return nil, ErrExpiryInPast
+	}
+
+	err := b.token.Sign(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.token, nil
+}
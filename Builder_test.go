@@ -0,0 +1,66 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestBuilderSign(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	exp := time.Now().Add(time.Hour)
+
+	// Act.
+	token, err := NewBuilder().
+		Issuer("Test Issuer").
+		Subject("user-1").
+		Audience("api").
+		Expiry(exp).
+		Scope("user:read").
+		Claim("name", "John Smith").
+		Sign(NewES256Signer(privateKey))
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, token.IsSigned()).IsTrue()
+	test.That(t, token.Verify(NewES256Verifier(&privateKey.PublicKey))).IsTrue()
+
+	iss, _ := token.GetStringClaim("iss")
+	test.That(t, iss).IsEqualTo("Test Issuer")
+
+	sub, _ := token.GetStringClaim("sub")
+	test.That(t, sub).IsEqualTo("user-1")
+
+	name, _ := token.GetStringClaim("name")
+	test.That(t, name).IsEqualTo("John Smith")
+
+	tokenExp, ok := token.GetExpiry()
+	test.That(t, ok).IsTrue()
+	test.That(t, tokenExp.Unix()).IsEqualTo(exp.Unix())
+
+	test.That(t, token.HasAudience("api")).IsTrue()
+	test.That(t, token.HasScope("user:read")).IsTrue()
+}
+
+func TestBuilderRejectsExpiryInPast(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	// Act.
+	token, err := NewBuilder().
+		Issuer("Test Issuer").
+		Expiry(time.Now().Add(-time.Minute)).
+		Sign(NewES256Signer(privateKey))
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrExpiryInPast)
+	test.That(t, token).IsNil()
+}
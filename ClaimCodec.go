@@ -0,0 +1,82 @@
+package jwt
+
+import "sync"
+
+// ClaimCodec converts a claim between its in-memory representation and the
+// representation it has in a serialized token.
+type ClaimCodec struct {
+	MarshalClaim   func(value interface{}) (interface{}, error)
+	UnmarshalClaim func(value interface{}) (interface{}, error)
+}
+
+var claimCodecs = struct {
+	sync.RWMutex
+	m map[string]ClaimCodec
+}{m: map[string]ClaimCodec{}}
+
+// RegisterClaimCodec registers the codec to use for the claim with the provided
+// name whenever a token is serialized or parsed, replacing any codec previously
+// registered for it.
+func RegisterClaimCodec(name string, codec ClaimCodec) {
+	claimCodecs.Lock()
+	defer claimCodecs.Unlock()
+
+	claimCodecs.m[name] = codec
+}
+
+// UnregisterClaimCodec removes the codec registered for the claim with the
+// provided name, if any.
+func UnregisterClaimCodec(name string) {
+	claimCodecs.Lock()
+	defer claimCodecs.Unlock()
+
+	delete(claimCodecs.m, name)
+}
+
+func marshalClaims(body Body) (Body, error) {
+	claimCodecs.RLock()
+	defer claimCodecs.RUnlock()
+
+	if len(claimCodecs.m) == 0 {
+		return body, nil
+	}
+
+	encoded := make(Body, len(body))
+	for name, value := range body {
+		codec, ok := claimCodecs.m[name]
+		if !ok || codec.MarshalClaim == nil {
+			encoded[name] = value
+			continue
+		}
+
+		encodedValue, err := codec.MarshalClaim(value)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded[name] = encodedValue
+	}
+
+	return encoded, nil
+}
+
+func unmarshalClaims(body Body) error {
+	claimCodecs.RLock()
+	defer claimCodecs.RUnlock()
+
+	for name, codec := range claimCodecs.m {
+		value, ok := body[name]
+		if !ok || codec.UnmarshalClaim == nil {
+			continue
+		}
+
+		decodedValue, err := codec.UnmarshalClaim(value)
+		if err != nil {
+			return err
+		}
+
+		body[name] = decodedValue
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestClaimCodecRoundTrip(t *testing.T) {
+	// Arrange.
+	defer UnregisterClaimCodec("codec_test_bin")
+	RegisterClaimCodec("codec_test_bin", ClaimCodec{
+		MarshalClaim: func(value interface{}) (interface{}, error) {
+			raw, ok := value.([]byte)
+			if !ok {
+				return nil, errors.New("expected []byte")
+			}
+
+			return base64.RawURLEncoding.EncodeToString(raw), nil
+		},
+		UnmarshalClaim: func(value interface{}) (interface{}, error) {
+			str, ok := value.(string)
+			if !ok {
+				return nil, errors.New("expected string")
+			}
+
+			return base64.RawURLEncoding.DecodeString(str)
+		},
+	})
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	token1 := NewToken()
+	token1.AddClaim("codec_test_bin", []byte{0xde, 0xad, 0xbe, 0xef})
+
+	err = token1.Sign(NewES256Signer(privateKey))
+	test.That(t, err).IsNil()
+
+	// Act.
+	tokenString, err := token1.Serialize()
+	test.That(t, err).IsNil()
+
+	token2, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	// Assert.
+	value, ok := token2.GetClaim("codec_test_bin")
+	test.That(t, ok).IsTrue()
+	test.That(t, value).HasEquivalentSequenceTo([]byte{0xde, 0xad, 0xbe, 0xef})
+
+	test.That(t, token1.Verify(NewES256Verifier(&privateKey.PublicKey))).IsTrue()
+	test.That(t, token2.Verify(NewES256Verifier(&privateKey.PublicKey))).IsTrue()
+}
+
+func TestClaimCodecSurfacesErrors(t *testing.T) {
+	// Arrange.
+	codecErr := errors.New("codec failure")
+
+	defer UnregisterClaimCodec("codec_test_err")
+	RegisterClaimCodec("codec_test_err", ClaimCodec{
+		MarshalClaim: func(value interface{}) (interface{}, error) {
+			return nil, codecErr
+		},
+	})
+
+	token := NewToken()
+	token.AddClaim("codec_test_err", "value")
+
+	// Act.
+	_, err := token.Serialize()
+
+	// Assert.
+	test.That(t, err).IsEqualTo(codecErr)
+}
+
+func TestUnregisterClaimCodec(t *testing.T) {
+	// Arrange.
+	RegisterClaimCodec("codec_test_unregistered", ClaimCodec{
+		MarshalClaim: func(value interface{}) (interface{}, error) {
+			return "encoded", nil
+		},
+	})
+
+	UnregisterClaimCodec("codec_test_unregistered")
+
+	token := NewToken()
+	token.AddClaim("codec_test_unregistered", "value")
+
+	tokenString, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	// Act.
+	parsed, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	value, _ := parsed.GetStringClaim("codec_test_unregistered")
+
+	// Assert.
+	test.That(t, value).IsEqualTo("value")
+}
@@ -0,0 +1,94 @@
+package jwt
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DPoPProofLifetime is the maximum difference allowed between the issue time of
+// a DPoP proof and the time it is verified at.
+const DPoPProofLifetime = 5 * time.Minute
+
+// ErrInvalidDPoPProof is returned when a DPoP proof is malformed or does not
+// match the request or access token it is presented with.
+var ErrInvalidDPoPProof = errors.New("the DPoP proof is invalid")
+
+// VerifyDPoPProof verifies a DPoP proof as described by RFC 9449.  The proof
+// must be of type dpop+jwt, be signed by the public key embedded in its jwk
+// header, be bound to the provided HTTP method and URL, have been issued within
+// DPoPProofLifetime of now and, if an access token is provided, be bound to it
+// via the ath claim.  Replay detection using the jti claim of the returned proof
+// is left to the caller.
+func VerifyDPoPProof(proof string, method, url string, accessToken string, now time.Time) (*Token, error) {
+	token, err := Parse(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(token.Header.Type, "dpop+jwt") {
+		return nil, ErrInvalidDPoPProof
+	}
+
+	jwk, ok := token.Header.Extra["jwk"].(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidDPoPProof
+	}
+
+	verifier, err := verifierFromJWK(jwk, token.Header.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Verify(verifier) {
+		return nil, ErrInvalidSignature
+	}
+
+	if _, ok := token.GetID(); !ok {
+		return nil, ErrInvalidDPoPProof
+	}
+
+	htm, _ := token.GetStringClaim("htm")
+	if htm != method {
+		return nil, ErrInvalidDPoPProof
+	}
+
+	htu, _ := token.GetStringClaim("htu")
+	if !dpopURLsMatch(htu, url) {
+		return nil, ErrInvalidDPoPProof
+	}
+
+	iat, ok := token.getTimeClaim("iat")
+	if !ok || iat.Sub(now) > DPoPProofLifetime || now.Sub(iat) > DPoPProofLifetime {
+		return nil, ErrInvalidDPoPProof
+	}
+
+	if accessToken != "" {
+		hash := sha256.Sum256([]byte(accessToken))
+		ath, _ := token.GetStringClaim("ath")
+		if !SecureCompare(ath, base64.RawURLEncoding.EncodeToString(hash[:])) {
+			return nil, ErrInvalidDPoPProof
+		}
+	}
+
+	return token, nil
+}
+
+func dpopURLsMatch(htu, target string) bool {
+	htuURL, err := url.Parse(htu)
+	if err != nil || htu == "" {
+		return false
+	}
+
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(htuURL.Scheme, targetURL.Scheme) &&
+		strings.EqualFold(htuURL.Host, targetURL.Host) &&
+		htuURL.EscapedPath() == targetURL.EscapedPath()
+}
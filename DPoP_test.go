@@ -0,0 +1,116 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestVerifyDPoPProofValid(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Now()
+	proof := newDPoPProof(t, privateKey, "POST", "https://api.example.com/resource", "access-token", now)
+
+	// Act.
+	token, err := VerifyDPoPProof(proof, "POST", "https://api.example.com/resource?x=1", "access-token", now)
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, token).IsNotNil()
+}
+
+func TestVerifyDPoPProofRejectsMismatches(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Now()
+	proof := newDPoPProof(t, privateKey, "POST", "https://api.example.com/resource", "access-token", now)
+
+	// Act.
+	_, methodErr := VerifyDPoPProof(proof, "GET", "https://api.example.com/resource", "access-token", now)
+	_, urlErr := VerifyDPoPProof(proof, "POST", "https://api.example.com/other", "access-token", now)
+	_, athErr := VerifyDPoPProof(proof, "POST", "https://api.example.com/resource", "other-token", now)
+	_, iatErr := VerifyDPoPProof(proof, "POST", "https://api.example.com/resource", "access-token", now.Add(time.Hour))
+
+	// Assert.
+	test.That(t, methodErr).IsEqualTo(ErrInvalidDPoPProof)
+	test.That(t, urlErr).IsEqualTo(ErrInvalidDPoPProof)
+	test.That(t, athErr).IsEqualTo(ErrInvalidDPoPProof)
+	test.That(t, iatErr).IsEqualTo(ErrInvalidDPoPProof)
+}
+
+func TestVerifyDPoPProofRejectsTamperedProof(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Now()
+	proof := newDPoPProof(t, privateKey, "POST", "https://api.example.com/resource", "access-token", now)
+
+	token, err := Parse(proof)
+	test.That(t, err).IsNil()
+
+	tampered := token.Unsign()
+	tampered.AddClaim("htm", "DELETE")
+	tampered.Signature = token.Signature
+
+	tamperedProof, err := tampered.Serialize()
+	test.That(t, err).IsNil()
+
+	spl := strings.Split(proof, ".")
+
+	// Act.
+	_, tamperedErr := VerifyDPoPProof(tamperedProof, "DELETE", "https://api.example.com/resource", "access-token", now)
+	_, structureErr := VerifyDPoPProof(spl[0]+"."+spl[1], "POST", "https://api.example.com/resource", "access-token", now)
+
+	// Assert.
+	test.That(t, tamperedErr).IsEqualTo(ErrInvalidSignature)
+	test.That(t, errors.Is(structureErr, ErrInvalidTokenStructure)).IsTrue()
+}
+
+func newDPoPProof(t *testing.T, privateKey *ecdsa.PrivateKey, method, url, accessToken string, now time.Time) string {
+	hash := sha256.Sum256([]byte(accessToken))
+
+	token := NewToken()
+	token.Header.Type = "dpop+jwt"
+	token.Header.Extra = map[string]interface{}{"jwk": ecJWK(&privateKey.PublicKey)}
+	token.AddClaim("htm", method)
+	token.AddClaim("htu", url)
+	token.AddClaim("iat", now.Unix())
+	token.AddClaim("ath", base64.RawURLEncoding.EncodeToString(hash[:]))
+
+	err := token.GenerateID()
+	test.That(t, err).IsNil()
+
+	err = token.Sign(NewES256Signer(privateKey))
+	test.That(t, err).IsNil()
+
+	proof, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	return proof
+}
+
+func ecJWK(publicKey *ecdsa.PublicKey) map[string]interface{} {
+	x := append(make([]byte, 32-len(publicKey.X.Bytes())), publicKey.X.Bytes()...)
+	y := append(make([]byte, 32-len(publicKey.Y.Bytes())), publicKey.Y.Bytes()...)
+
+	return map[string]interface{}{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(x),
+		"y":   base64.RawURLEncoding.EncodeToString(y),
+	}
+}
@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ErrDuplicateHeaderParam is returned when the header of a token contains the
+// same parameter more than once, including parameters whose names differ only in
+// case.
+var ErrDuplicateHeaderParam = errors.New("the token header contains a duplicate parameter")
+
+// ErrDuplicateClaim is returned when the body of a token contains the same claim
+// more than once.
+var ErrDuplicateClaim = errors.New("the token body contains a duplicate claim")
+
+// hasDuplicateKeys returns true if any object in the provided JSON, at any depth,
+// contains the same key more than once.  Malformed JSON is reported as having no
+// duplicates so that the error from decoding it is surfaced instead.
+func hasDuplicateKeys(data []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	duplicate, _ := scanDuplicateKeys(decoder)
+	return duplicate
+}
+
+// hasDuplicateHeaderParams returns true if the provided JSON header has
+// duplicate keys, or top-level keys that are equal under case folding.
+// encoding/json matches keys to the fields of Header case-insensitively with the
+// last key winning, so {"alg":"HS256","ALG":"None"} would otherwise decode to a
+// different algorithm than a case-sensitive reader sees.
+func hasDuplicateHeaderParams(data []byte) bool {
+	if hasDuplicateKeys(data) {
+		return true
+	}
+
+	params := map[string]json.RawMessage{}
+	err := json.Unmarshal(data, &params)
+	if err != nil {
+		return false
+	}
+
+	folded := make(map[string]struct{}, len(params))
+	for k := range params {
+		key := foldKey(k)
+		if _, ok := folded[key]; ok {
+			return true
+		}
+
+		folded[key] = struct{}{}
+	}
+
+	return false
+}
+
+// foldKey maps each rune of the provided key to the smallest rune it is equal
+// to under simple case folding, so keys that are equal under strings.EqualFold
+// map to the same string.
+func foldKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+
+		return min
+	}, key)
+}
+
+func scanDuplicateKeys(decoder *json.Decoder) (bool, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return false, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return false, nil
+	}
+
+	switch delim {
+	case '{':
+		keys := map[string]struct{}{}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return false, err
+			}
+
+			key, _ := keyToken.(string)
+			if _, ok := keys[key]; ok {
+				return true, nil
+			}
+
+			keys[key] = struct{}{}
+
+			duplicate, err := scanDuplicateKeys(decoder)
+			if duplicate || err != nil {
+				return duplicate, err
+			}
+		}
+	case '[':
+		for decoder.More() {
+			duplicate, err := scanDuplicateKeys(decoder)
+			if duplicate || err != nil {
+				return duplicate, err
+			}
+		}
+	}
+
+	_, err = decoder.Token()
+	return false, err
+}
@@ -0,0 +1,103 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestParseRejectsDuplicateClaims(t *testing.T) {
+	// Arrange.
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","typ":"JWT"}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1,"iss":"Test Issuer","exp":9999999999}`))
+
+	// Act.
+	token, err := Parse(header + "." + body + ".c2ln")
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrDuplicateClaim)
+	test.That(t, token).IsNil()
+}
+
+func TestParseRejectsDuplicateHeaderParams(t *testing.T) {
+	// Arrange.
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","typ":"JWT","alg":"None"}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"Test Issuer"}`))
+
+	// Act.
+	token, err := Parse(header + "." + body + ".")
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrDuplicateHeaderParam)
+	test.That(t, token).IsNil()
+}
+
+func TestHasDuplicateKeys(t *testing.T) {
+	// Arrange.
+	testCases := []struct {
+		json      string
+		duplicate bool
+	}{
+		{`{"a":1,"b":2}`, false},
+		{`{"a":1,"a":2}`, true},
+		{`{"a":{"b":1,"b":2}}`, true},
+		{`{"a":[{"b":1},{"b":2}]}`, false},
+		{`{"a":[{"b":1,"b":2}]}`, true},
+		{`{"a":{"b":1},"c":{"b":2}}`, false},
+		{`[1,2,3]`, false},
+		{`{"a":`, false},
+	}
+
+	for _, testCase := range testCases {
+		// Act.
+		duplicate := hasDuplicateKeys([]byte(testCase.json))
+
+		// Assert.
+		test.That(t, duplicate).IsEqualTo(testCase.duplicate)
+	}
+}
+
+func TestParseRejectsCaseFoldedDuplicateHeaderParams(t *testing.T) {
+	// Arrange.
+	body := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"Test Issuer"}`))
+	headers := []string{
+		`{"alg":"HS256","typ":"JWT","ALG":"None"}`,
+		`{"alg":"HS256","typ":"JWT","Alg":"None"}`,
+		`{"alg":"HS256","typ":"JWT","kid":"a","\u212aid":"b"}`,
+	}
+
+	for _, rawHeader := range headers {
+		header := base64.RawURLEncoding.EncodeToString([]byte(rawHeader))
+
+		// Act.
+		_, err := Parse(header + "." + body + ".c2ln")
+		_, strictErr := ParseStrict(header + "." + body + ".c2ln")
+
+		// Assert.
+		test.That(t, err).IsEqualTo(ErrDuplicateHeaderParam)
+		test.That(t, strictErr).IsEqualTo(ErrDuplicateHeaderParam)
+	}
+}
+
+func TestHasDuplicateHeaderParams(t *testing.T) {
+	// Arrange.
+	testCases := []struct {
+		json      string
+		duplicate bool
+	}{
+		{`{"alg":"HS256","typ":"JWT"}`, false},
+		{`{"alg":"HS256","ALG":"None"}`, true},
+		{`{"x5t":"a","X5T":"b"}`, true},
+		{`{"alg":"HS256","nested":{"a":1,"A":2}}`, false},
+		{`{"alg":`, false},
+	}
+
+	for _, testCase := range testCases {
+		// Act.
+		duplicate := hasDuplicateHeaderParams([]byte(testCase.json))
+
+		// Assert.
+		test.That(t, duplicate).IsEqualTo(testCase.duplicate)
+	}
+}
@@ -0,0 +1,45 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+)
+
+// ES256DERVerifier verifies JWT tokens using the ES256 algorithm, accepting
+// signatures encoded either as ASN.1 DER, as emitted by some non-conformant
+// producers, or as the fixed-width r||s concatenation mandated by JWS.
+type ES256DERVerifier struct {
+	publicKey *ecdsa.PublicKey
+}
+
+var _ Verifier = &ES256DERVerifier{}
+
+type derSignature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// NewES256DERVerifier creates a new ES256DERVerifier with the provided ECDSA
+// Public Key.
+func NewES256DERVerifier(publicKey *ecdsa.PublicKey) *ES256DERVerifier {
+	return &ES256DERVerifier{
+		publicKey: publicKey,
+	}
+}
+
+// Verify verifies the provided serialized header and body against the provided
+// signature.
+func (v *ES256DERVerifier) Verify(b64HeaderAndBody string, signature []byte) bool {
+	sig := derSignature{}
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil || len(rest) != 0 || sig.R == nil || sig.S == nil {
+		return NewES256Verifier(v.publicKey).Verify(b64HeaderAndBody, signature)
+	}
+
+	hashArr := sha256.Sum256([]byte(b64HeaderAndBody))
+	hash := hashArr[:]
+
+	return ecdsa.Verify(v.publicKey, hash, sig.R, sig.S)
+}
@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestES256DERVerifierAcceptsDERSignature(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	b64HeaderAndBody := "eyJhbGciOiJFUzI1NiIsInR5cCI6IkpXVCJ9.eyJpc3MiOiJUZXN0IElzc3VlciJ9"
+	hash := sha256.Sum256([]byte(b64HeaderAndBody))
+
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
+	test.That(t, err).IsNil()
+
+	signature, err := asn1.Marshal(derSignature{R: r, S: s})
+	test.That(t, err).IsNil()
+
+	// Act.
+	derValid := NewES256DERVerifier(&privateKey.PublicKey).Verify(b64HeaderAndBody, signature)
+	fixedValid := NewES256Verifier(&privateKey.PublicKey).Verify(b64HeaderAndBody, signature)
+	tamperedValid := NewES256DERVerifier(&privateKey.PublicKey).Verify(b64HeaderAndBody+"x", signature)
+
+	// Assert.
+	test.That(t, derValid).IsTrue()
+	test.That(t, fixedValid).IsFalse()
+	test.That(t, tamperedValid).IsFalse()
+}
+
+func TestES256DERVerifierAcceptsFixedWidthSignature(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	token := NewToken()
+	token.AddClaim("iss", "Test Issuer")
+
+	err = token.Sign(NewES256Signer(privateKey))
+	test.That(t, err).IsNil()
+
+	// Act.
+	valid := token.Verify(NewES256DERVerifier(&privateKey.PublicKey))
+
+	// Assert.
+	test.That(t, valid).IsTrue()
+}
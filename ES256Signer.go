@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/sha256"
+	"errors"
 )
 
 // ES256Signer signs JWT tokens using the ES256 algorithm.
@@ -13,6 +14,10 @@ type ES256Signer struct {
 
 var _ Signer = &ES256Signer{}
 
+// ErrSignerZeroized is returned when attempting to sign with a signer whose key
+// material has been cleared.
+var ErrSignerZeroized = errors.New("the signer's key material has been cleared")
+
 // NewES256Signer creates a new ES256Signer with the provided ECDSA Private
 // Key.
 func NewES256Signer(privateKey *ecdsa.PrivateKey) *ES256Signer {
@@ -28,6 +33,10 @@ func (s *ES256Signer) Algorithm() Algorithm {
 
 // Sign signs the provided serialized header and body.
 func (s *ES256Signer) Sign(b64HeaderAndBody string) ([]byte, error) {
+	if s.privateKey == nil {
+		return nil, ErrSignerZeroized
+	}
+
 	hashArr := sha256.Sum256([]byte(b64HeaderAndBody))
 	hash := hashArr[:]
 
@@ -44,3 +53,26 @@ func (s *ES256Signer) Sign(b64HeaderAndBody string) ([]byte, error) {
 
 	return append(rrp, srp...), nil
 }
+
+// Zeroize clears the private scalar of the signer's private key and releases
+// the signer's reference to it, after which Sign returns ErrSignerZeroized.
+// Because the key is shared with the caller, the caller's copy is cleared too.
+// This is best-effort only: the Go runtime may have copied the key material
+// elsewhere in memory (e.g. during garbage collection or inside crypto/ecdsa),
+// and those copies cannot be reached from here.
+func (s *ES256Signer) Zeroize() {
+	if s.privateKey == nil {
+		return
+	}
+
+	if s.privateKey.D != nil {
+		words := s.privateKey.D.Bits()
+		for i := range words {
+			words[i] = 0
+		}
+
+		s.privateKey.D.SetInt64(0)
+	}
+
+	s.privateKey = nil
+}
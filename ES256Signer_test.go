@@ -0,0 +1,45 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestES256SignerZeroize(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey)
+
+	// Act.
+	signer.Zeroize()
+	signature, err := signer.Sign("header.body")
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrSignerZeroized)
+	test.That(t, len(signature)).IsEqualTo(0)
+	test.That(t, privateKey.D.Sign()).IsEqualTo(0)
+}
+
+func TestES256SignerZeroizeTokenSign(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey)
+	token := NewToken()
+
+	// Act.
+	signer.Zeroize()
+	signer.Zeroize()
+	err = token.Sign(signer)
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrSignerZeroized)
+	test.That(t, token.IsSigned()).IsFalse()
+}
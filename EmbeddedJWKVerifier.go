@@ -0,0 +1,59 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// EmbeddedJWKVerifier verifies JWT tokens using the public key embedded in the
+// jwk parameter of their header.  Because anyone can embed their own key in a
+// token, a valid signature only proves that the token was signed by the holder
+// of the embedded key, so the caller must decide which embedded keys to trust.
+type EmbeddedJWKVerifier struct {
+	trust func(jwk map[string]interface{}) bool
+}
+
+var _ Verifier = &EmbeddedJWKVerifier{}
+
+// NewEmbeddedJWKVerifier creates a new EmbeddedJWKVerifier that only verifies
+// tokens whose embedded key is accepted by trust.  If trust is nil, no embedded
+// keys are trusted and every token is rejected.
+func NewEmbeddedJWKVerifier(trust func(jwk map[string]interface{}) bool) *EmbeddedJWKVerifier {
+	return &EmbeddedJWKVerifier{
+		trust: trust,
+	}
+}
+
+// Verify verifies the provided serialized header and body against the provided
+// signature using the key embedded in the header.
+func (v *EmbeddedJWKVerifier) Verify(b64HeaderAndBody string, signature []byte) bool {
+	if v.trust == nil {
+		return false
+	}
+
+	b64Header := strings.SplitN(b64HeaderAndBody, ".", 2)[0]
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(b64Header)
+	if err != nil {
+		return false
+	}
+
+	header := Header{}
+	err = json.Unmarshal(rawHeader, &header)
+	if err != nil {
+		return false
+	}
+
+	jwk, ok := header.Extra["jwk"].(map[string]interface{})
+	if !ok || !v.trust(jwk) {
+		return false
+	}
+
+	verifier, err := verifierFromJWK(jwk, header.Algorithm)
+	if err != nil {
+		return false
+	}
+
+	return verifier.Verify(b64HeaderAndBody, signature)
+}
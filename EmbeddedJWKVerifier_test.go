@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestEmbeddedJWKVerifierValid(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	token := NewToken()
+	token.Header.Extra = map[string]interface{}{"jwk": ecJWK(&privateKey.PublicKey)}
+
+	err = token.Sign(NewES256Signer(privateKey))
+	test.That(t, err).IsNil()
+
+	trustAll := func(jwk map[string]interface{}) bool { return true }
+
+	// Act.
+	trusted := token.Verify(NewEmbeddedJWKVerifier(trustAll))
+	untrusted := token.Verify(NewEmbeddedJWKVerifier(nil))
+
+	// Assert.
+	test.That(t, trusted).IsTrue()
+	test.That(t, untrusted).IsFalse()
+}
+
+func TestEmbeddedJWKVerifierMismatchedKey(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	token := NewToken()
+	token.Header.Extra = map[string]interface{}{"jwk": ecJWK(&otherKey.PublicKey)}
+
+	err = token.Sign(NewES256Signer(privateKey))
+	test.That(t, err).IsNil()
+
+	trustAll := func(jwk map[string]interface{}) bool { return true }
+
+	// Act.
+	valid := token.Verify(NewEmbeddedJWKVerifier(trustAll))
+
+	// Assert.
+	test.That(t, valid).IsFalse()
+}
@@ -0,0 +1,135 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+)
+
+// Equal returns true if the token and other have the same header, claims and
+// signature.  Claims are compared semantically, so a claim holding a []string is
+// equal to one holding an equivalent []interface{}, and numeric claims are equal
+// if they represent exactly the same number, regardless of their Go types.
+func (t *Token) Equal(other *Token) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+
+	t.ensureBody()
+	other.ensureBody()
+
+	return t.Header.Algorithm == other.Header.Algorithm &&
+		t.Header.Type == other.Header.Type &&
+		t.Header.KeyID == other.Header.KeyID &&
+		valuesEqual(t.Header.Extra, other.Header.Extra) &&
+		valuesEqual(t.Body, other.Body) &&
+		bytes.Equal(t.Signature, other.Signature)
+}
+
+func valuesEqual(a, b interface{}) bool {
+	a = normalizeValue(a)
+	b = normalizeValue(b)
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for k, v := range av {
+			w, ok := bv[k]
+			if !ok || !valuesEqual(v, w) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for i := range av {
+			if !valuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Body:
+		return map[string]interface{}(v)
+	case map[string]interface{}:
+		if v == nil {
+			return map[string]interface{}{}
+		}
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = e
+		}
+
+		return m
+	case []string:
+		s := make([]interface{}, len(v))
+		for i, e := range v {
+			s[i] = e
+		}
+
+		return s
+	case int:
+		return exactNumber(new(big.Rat).SetInt64(int64(v)).RatString())
+	case int8:
+		return exactNumber(new(big.Rat).SetInt64(int64(v)).RatString())
+	case int16:
+		return exactNumber(new(big.Rat).SetInt64(int64(v)).RatString())
+	case int32:
+		return exactNumber(new(big.Rat).SetInt64(int64(v)).RatString())
+	case int64:
+		return exactNumber(new(big.Rat).SetInt64(v).RatString())
+	case uint:
+		return exactNumber(new(big.Rat).SetUint64(uint64(v)).RatString())
+	case uint8:
+		return exactNumber(new(big.Rat).SetUint64(uint64(v)).RatString())
+	case uint16:
+		return exactNumber(new(big.Rat).SetUint64(uint64(v)).RatString())
+	case uint32:
+		return exactNumber(new(big.Rat).SetUint64(uint64(v)).RatString())
+	case uint64:
+		return exactNumber(new(big.Rat).SetUint64(v).RatString())
+	case float32:
+		return normalizeFloat(float64(v))
+	case float64:
+		return normalizeFloat(v)
+	case json.Number:
+		r, ok := new(big.Rat).SetString(string(v))
+		if ok {
+			return exactNumber(r.RatString())
+		}
+	}
+
+	return value
+}
+
+// exactNumber is the exact rational value of a number, so that numbers of
+// different Go types can be compared without the loss of precision that
+// converting them to a common type such as float64 would incur.
+type exactNumber string
+
+func normalizeFloat(f float64) interface{} {
+	r := new(big.Rat).SetFloat64(f)
+	if r == nil {
+		return f
+	}
+
+	return exactNumber(r.RatString())
+}
@@ -0,0 +1,96 @@
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestTokenEqual(t *testing.T) {
+	// Arrange.
+	token1 := NewToken()
+	token1.AddClaim("iss", "Test Issuer")
+	token1.AddClaim("exp", 86400)
+	token1.AddClaim("nested", map[string]interface{}{"n": int64(5)})
+	token1.AddScope("user:read")
+	token1.AddScope("user:create")
+	token1.Signature = []byte{1, 2, 3, 4}
+
+	tokenString, err := token1.Serialize()
+	test.That(t, err).IsNil()
+
+	token2, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	testCases := []struct {
+		name   string
+		mutate func(token *Token)
+		equal  bool
+	}{
+		{"parsed", func(token *Token) {}, true},
+		{"float exp", func(token *Token) { token.Body["exp"] = float64(86400) }, true},
+		{"different exp", func(token *Token) { token.Body["exp"] = 86401 }, false},
+		{"json number exp", func(token *Token) { token.Body["exp"] = json.Number("86400") }, true},
+		{"fractional exp", func(token *Token) { token.Body["exp"] = 86400.5 }, false},
+		{"string slice scope", func(token *Token) { token.Body["scope"] = []string{"user:read", "user:create"} }, true},
+		{"reordered scope", func(token *Token) { token.Body["scope"] = []string{"user:create", "user:read"} }, false},
+		{"extra claim", func(token *Token) { token.Body["sub"] = "user-1" }, false},
+		{"different nested", func(token *Token) { token.Body["nested"] = map[string]interface{}{"n": 6} }, false},
+		{"different signature", func(token *Token) { token.Signature = []byte{1, 2, 3, 5} }, false},
+		{"different type", func(token *Token) { token.Header.Type = "at+jwt" }, false},
+		{"different extra", func(token *Token) { token.Header.Extra = map[string]interface{}{"x5t": "x"} }, false},
+	}
+
+	for _, testCase := range testCases {
+		other := token2.Clone()
+		testCase.mutate(other)
+
+		// Act.
+		equal := token1.Equal(other)
+
+		// Assert.
+		test.That(t, equal).IsEqualTo(testCase.equal)
+	}
+}
+
+func TestTokenEqualComparesLargeIntegersExactly(t *testing.T) {
+	// Arrange.
+	token1 := NewToken()
+	token1.AddClaim("n", uint64(1<<63))
+
+	token2 := NewToken()
+	token2.AddClaim("n", uint64(1<<63+1))
+
+	token3 := NewToken()
+	token3.AddClaim("n", json.Number("9223372036854775808"))
+
+	token4 := NewToken()
+	token4.AddClaim("n", int64(1<<62+1))
+
+	token5 := NewToken()
+	token5.AddClaim("n", int64(1<<62))
+
+	// Act.
+	distinctUnsigned := token1.Equal(token2)
+	sameNumber := token1.Equal(token3)
+	distinctSigned := token4.Equal(token5)
+
+	// Assert.
+	test.That(t, distinctUnsigned).IsFalse()
+	test.That(t, sameNumber).IsTrue()
+	test.That(t, distinctSigned).IsFalse()
+}
+
+func TestTokenEqualNil(t *testing.T) {
+	// Arrange.
+	var nilToken *Token
+
+	// Act.
+	nilEqual := nilToken.Equal(nil)
+	nonNilEqual := NewToken().Equal(nil)
+
+	// Assert.
+	test.That(t, nilEqual).IsTrue()
+	test.That(t, nonNilEqual).IsFalse()
+}
@@ -0,0 +1,20 @@
+package jwt
+
+// FailureReason is an alias for string that categorizes why a token failed
+// authorization.  Unlike error strings, the set of reasons is fixed, making them
+// suitable for use as metric labels.
+type FailureReason string
+
+// The reasons a token can fail authorization.
+const (
+	ReasonNone              FailureReason = ""
+	ReasonMalformed         FailureReason = "malformed"
+	ReasonWrongAlgorithm    FailureReason = "wrong_algorithm"
+	ReasonBadSignature      FailureReason = "bad_signature"
+	ReasonExpired           FailureReason = "expired"
+	ReasonNotYetValid       FailureReason = "not_yet_valid"
+	ReasonWrongIssuer       FailureReason = "wrong_issuer"
+	ReasonWrongAudience     FailureReason = "wrong_audience"
+	ReasonInsufficientScope FailureReason = "insufficient_scope"
+	ReasonInvalidClaims     FailureReason = "invalid_claims"
+)
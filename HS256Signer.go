@@ -0,0 +1,33 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// HS256Signer signs JWT tokens using the HS256 algorithm.
+type HS256Signer struct {
+	secret []byte
+}
+
+var _ Signer = &HS256Signer{}
+
+// NewHS256Signer creates a new HS256Signer with the provided shared secret.
+func NewHS256Signer(secret []byte) *HS256Signer {
+	return &HS256Signer{
+		secret: secret,
+	}
+}
+
+// Algorithm returns HS256.
+func (s *HS256Signer) Algorithm() Algorithm {
+	return HS256
+}
+
+// Sign signs the provided serialized header and body.
+func (s *HS256Signer) Sign(b64HeaderAndBody string) ([]byte, error) {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(b64HeaderAndBody))
+
+	return mac.Sum(nil), nil
+}
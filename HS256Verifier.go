@@ -0,0 +1,29 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// HS256Verifier verifies JWT tokens using the HS256 algorithm.
+type HS256Verifier struct {
+	secret []byte
+}
+
+var _ Verifier = &HS256Verifier{}
+
+// NewHS256Verifier creates a new HS256Verifier with the provided shared secret.
+func NewHS256Verifier(secret []byte) *HS256Verifier {
+	return &HS256Verifier{
+		secret: secret,
+	}
+}
+
+// Verify verifies the provided serialized header and body against the provided
+// signature.
+func (v *HS256Verifier) Verify(b64HeaderAndBody string, signature []byte) bool {
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(b64HeaderAndBody))
+
+	return hmac.Equal(mac.Sum(nil), signature)
+}
@@ -1,11 +1,22 @@
 package jwt
 
-// Header represents a JWT header.
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Header represents a JWT header.  Any parameters beyond the registered ones
+// are held in Extra, which is flattened into the header when it is serialized.
 type Header struct {
-	Algorithm Algorithm `json:"alg"`
-	Type      string    `json:"typ"`
+	Algorithm Algorithm              `json:"alg"`
+	Type      string                 `json:"typ"`
+	KeyID     string                 `json:"kid,omitempty"`
+	Extra     map[string]interface{} `json:"-"`
 }
 
+// plainHeader has the same fields as Header, without its JSON methods.
+type plainHeader Header
+
 // NewHeader creates a new Header.
 func NewHeader() Header {
 	return Header{
@@ -13,3 +24,82 @@ func NewHeader() Header {
 		Type:      "JWT",
 	}
 }
+
+// MarshalJSON serializes the header, flattening Extra into the same object.
+// Extra parameters that collide with a registered parameter are ignored.
+func (h Header) MarshalJSON() ([]byte, error) {
+	rawHeader, err := json.Marshal(plainHeader(h))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(h.Extra) == 0 {
+		return rawHeader, nil
+	}
+
+	registered := map[string]json.RawMessage{}
+	err = json.Unmarshal(rawHeader, &registered)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := make(map[string]interface{}, len(h.Extra))
+	for k, v := range h.Extra {
+		if _, ok := registered[k]; !ok {
+			extra[k] = v
+		}
+	}
+
+	if len(extra) == 0 {
+		return rawHeader, nil
+	}
+
+	rawExtra, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(rawHeader[:len(rawHeader)-1])
+	buf.WriteByte(',')
+	buf.Write(rawExtra[1:])
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON deserializes the header, capturing any parameters that are not
+// registered into Extra.
+func (h *Header) UnmarshalJSON(data []byte) error {
+	header := plainHeader{}
+	err := json.Unmarshal(data, &header)
+	if err != nil {
+		return err
+	}
+
+	rawHeader, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	registered := map[string]json.RawMessage{}
+	err = json.Unmarshal(rawHeader, &registered)
+	if err != nil {
+		return err
+	}
+
+	extra := map[string]interface{}{}
+	err = json.Unmarshal(data, &extra)
+	if err != nil {
+		return err
+	}
+
+	for k := range registered {
+		delete(extra, k)
+	}
+
+	if len(extra) > 0 {
+		header.Extra = extra
+	}
+
+	*h = Header(header)
+	return nil
+}
@@ -0,0 +1,56 @@
+package jwt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestHeaderMarshalWithoutExtra(t *testing.T) {
+	// Arrange.
+	header := NewHeader()
+
+	// Act.
+	rawHeader, err := json.Marshal(header)
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, string(rawHeader)).IsEqualTo(`{"alg":"None","typ":"JWT"}`)
+}
+
+func TestHeaderMarshalFlattensExtra(t *testing.T) {
+	// Arrange.
+	header := NewHeader()
+	header.Extra = map[string]interface{}{
+		"x5t": "thumbprint",
+		"cty": "JWT",
+		"alg": "ignored",
+	}
+
+	// Act.
+	rawHeader, err := json.Marshal(header)
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, string(rawHeader)).IsEqualTo(`{"alg":"None","typ":"JWT","cty":"JWT","x5t":"thumbprint"}`)
+}
+
+func TestHeaderUnmarshalCapturesExtra(t *testing.T) {
+	// Arrange.
+	rawHeader := []byte(`{"alg":"ES256","typ":"JWT","x5t":"thumbprint","vnd":{"a":1}}`)
+
+	// Act.
+	header := Header{}
+	err := json.Unmarshal(rawHeader, &header)
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, header.Algorithm).IsEqualTo(ES256)
+	test.That(t, header.Type).IsEqualTo("JWT")
+	test.That(t, len(header.Extra)).IsEqualTo(2)
+	test.That(t, header.Extra["x5t"]).IsEqualTo("thumbprint")
+
+	_, hasAlg := header.Extra["alg"]
+	test.That(t, hasAlg).IsFalse()
+}
@@ -0,0 +1,32 @@
+package jwt
+
+import "time"
+
+// IssueWithID creates a token with the provided claims, a random unique
+// identifier and an expiry time ttl from now, and signs it with the provided
+// Signer.  The serialized token is returned along with its identifier so that
+// it can be recorded for revocation.  The provided body is not modified.
+func IssueWithID(signer Signer, body Body, ttl time.Duration) (string, string, error) {
+	token := NewToken()
+	token.Body = cloneValue(body).(Body)
+
+	err := token.GenerateID()
+	if err != nil {
+		return "", "", err
+	}
+
+	token.SetExpiry(time.Now().Add(ttl))
+
+	err = token.Sign(signer)
+	if err != nil {
+		return "", "", err
+	}
+
+	tokenString, err := token.Serialize()
+	if err != nil {
+		return "", "", err
+	}
+
+	jti, _ := token.GetID()
+	return tokenString, jti, nil
+}
@@ -0,0 +1,38 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestIssueWithID(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	body := Body{"iss": "Test Issuer"}
+
+	// Act.
+	tokenString, jti, err := IssueWithID(NewES256Signer(privateKey), body, time.Hour)
+	test.That(t, err).IsNil()
+
+	token, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	// Assert.
+	test.That(t, token.Verify(NewES256Verifier(&privateKey.PublicKey))).IsTrue()
+	test.That(t, token.IsExpired(time.Now())).IsFalse()
+	test.That(t, token.IsExpired(time.Now().Add(2*time.Hour))).IsTrue()
+
+	id, ok := token.GetID()
+	test.That(t, ok).IsTrue()
+	test.That(t, id).IsEqualTo(jti)
+
+	_, ok = body["jti"]
+	test.That(t, ok).IsFalse()
+}
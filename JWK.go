@@ -0,0 +1,79 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidJWK is returned when a JSON Web Key is malformed, unsupported or
+// does not match the algorithm it is used with.
+var ErrInvalidJWK = errors.New("the JSON web key is invalid")
+
+func verifierFromJWK(jwk map[string]interface{}, alg Algorithm) (Verifier, error) {
+	if _, ok := jwk["d"]; ok {
+		return nil, ErrInvalidJWK
+	}
+
+	kty, _ := jwk["kty"].(string)
+
+	switch {
+	case alg == ES256 && kty == "EC":
+		crv, _ := jwk["crv"].(string)
+		if crv != "P-256" {
+			return nil, ErrInvalidJWK
+		}
+
+		x, err := jwkInt(jwk, "x")
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := jwkInt(jwk, "y")
+		if err != nil {
+			return nil, err
+		}
+
+		curve := elliptic.P256()
+		if !curve.IsOnCurve(x, y) {
+			return nil, ErrInvalidJWK
+		}
+
+		return NewES256Verifier(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}), nil
+	case alg == RS256 && kty == "RSA":
+		n, err := jwkInt(jwk, "n")
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := jwkInt(jwk, "e")
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, ErrInvalidJWK
+		}
+
+		return NewRS256Verifier(&rsa.PublicKey{N: n, E: int(e.Int64())}), nil
+	}
+
+	return nil, ErrInvalidJWK
+}
+
+func jwkInt(jwk map[string]interface{}, name string) (*big.Int, error) {
+	str, ok := jwk[name].(string)
+	if !ok {
+		return nil, ErrInvalidJWK
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil || len(raw) == 0 {
+		return nil, ErrInvalidJWK
+	}
+
+	return new(big.Int).SetBytes(raw), nil
+}
@@ -0,0 +1,133 @@
+package jwt
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultNegativeCacheTTL is how long a KeyCache remembers that a key
+// identifier is unknown by default.
+const DefaultNegativeCacheTTL = time.Minute
+
+// MaxNegativeCacheEntries is the maximum number of unknown key identifiers that
+// a KeyCache remembers at once, which bounds the memory an attacker can consume
+// by presenting random key identifiers.  When the limit is reached, the entry
+// that expires soonest is evicted to make room.
+const MaxNegativeCacheEntries = 1024
+
+// KeyFetcher fetches the Verifier for a key identifier from an external source,
+// such as a JWKS endpoint.  It returns ErrUnknownKeyID if the source does not
+// know the key identifier.
+type KeyFetcher func(kid string) (Verifier, error)
+
+// KeyCache verifies tokens using verifiers fetched on demand by their kid
+// header.  Fetched verifiers are cached indefinitely.  Key identifiers that the
+// fetcher reports as unknown are remembered for a TTL, during which lookups
+// fail with ErrUnknownKeyID without fetching again.  Other fetch errors are not
+// cached.
+type KeyCache struct {
+	mx          sync.Mutex
+	fetch       KeyFetcher
+	negativeTTL time.Duration
+	clock       func() time.Time
+	verifiers   map[string]Verifier
+	unknown     map[string]time.Time
+}
+
+// NewKeyCache creates a new KeyCache that fetches verifiers with the provided
+// fetcher and remembers unknown key identifiers for negativeTTL.
+func NewKeyCache(fetch KeyFetcher, negativeTTL time.Duration) *KeyCache {
+	return &KeyCache{
+		fetch:       fetch,
+		negativeTTL: negativeTTL,
+		clock:       time.Now,
+		verifiers:   map[string]Verifier{},
+		unknown:     map[string]time.Time{},
+	}
+}
+
+// VerifierFor returns the Verifier for the key with the provided identifier,
+// fetching it if it is not already cached.
+func (c *KeyCache) VerifierFor(kid string) (Verifier, error) {
+	c.mx.Lock()
+	verifier, ok := c.verifiers[kid]
+	expiry, isUnknown := c.unknown[kid]
+	now := c.clock()
+	c.mx.Unlock()
+
+	if ok {
+		return verifier, nil
+	}
+
+	if isUnknown && now.Before(expiry) {
+		return nil, ErrUnknownKeyID
+	}
+
+	verifier, err := c.fetch(kid)
+	if err == nil && verifier == nil {
+		err = ErrUnknownKeyID
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if errors.Is(err, ErrUnknownKeyID) {
+		c.rememberUnknown(kid, now)
+		return nil, ErrUnknownKeyID
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	delete(c.unknown, kid)
+	c.verifiers[kid] = verifier
+
+	return verifier, nil
+}
+
+// Verify verifies the signature on the token using the key identified by its
+// kid header.
+func (c *KeyCache) Verify(token *Token) error {
+	verifier, err := c.VerifierFor(token.Header.KeyID)
+	if err != nil {
+		return err
+	}
+
+	if !token.Verify(verifier) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (c *KeyCache) rememberUnknown(kid string, now time.Time) {
+	_, ok := c.unknown[kid]
+	if !ok && len(c.unknown) >= MaxNegativeCacheEntries {
+		c.evictUnknown(now)
+	}
+
+	c.unknown[kid] = now.Add(c.negativeTTL)
+}
+
+func (c *KeyCache) evictUnknown(now time.Time) {
+	soonestKid := ""
+	soonestExpiry := time.Time{}
+
+	for k, expiry := range c.unknown {
+		if !now.Before(expiry) {
+			delete(c.unknown, k)
+			continue
+		}
+
+		if soonestExpiry.IsZero() || expiry.Before(soonestExpiry) {
+			soonestKid = k
+			soonestExpiry = expiry
+		}
+	}
+
+	if len(c.unknown) >= MaxNegativeCacheEntries {
+		delete(c.unknown, soonestKid)
+	}
+}
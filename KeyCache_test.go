@@ -0,0 +1,121 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestKeyCacheDoesNotRefetchUnknownKeyWithinTTL(t *testing.T) {
+	// Arrange.
+	fetches := 0
+	cache := NewKeyCache(func(kid string) (Verifier, error) {
+		fetches++
+		return nil, ErrUnknownKeyID
+	}, time.Minute)
+
+	now := time.Unix(1600000000, 0)
+	cache.clock = func() time.Time { return now }
+
+	// Act.
+	_, err1 := cache.VerifierFor("missing")
+	_, err2 := cache.VerifierFor("missing")
+
+	now = now.Add(time.Minute)
+	_, err3 := cache.VerifierFor("missing")
+
+	// Assert.
+	test.That(t, err1).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, err2).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, err3).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, fetches).IsEqualTo(2)
+}
+
+func TestKeyCacheEvictsSoonestExpiringUnknownKeyWhenFull(t *testing.T) {
+	// Arrange.
+	fetches := 0
+	cache := NewKeyCache(func(kid string) (Verifier, error) {
+		fetches++
+		return nil, ErrUnknownKeyID
+	}, time.Minute)
+
+	now := time.Unix(1600000000, 0)
+	cache.clock = func() time.Time { return now }
+
+	for i := 0; i < MaxNegativeCacheEntries; i++ {
+		_, err := cache.VerifierFor(fmt.Sprintf("kid-%v", i))
+		test.That(t, err).IsEqualTo(ErrUnknownKeyID)
+		now = now.Add(time.Millisecond)
+	}
+
+	// Act.
+	_, err1 := cache.VerifierFor("new")
+	_, err2 := cache.VerifierFor("new")
+
+	// Assert.
+	test.That(t, err1).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, err2).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, fetches).IsEqualTo(MaxNegativeCacheEntries + 1)
+	test.That(t, len(cache.unknown)).IsEqualTo(MaxNegativeCacheEntries)
+
+	_, ok := cache.unknown["kid-0"]
+	test.That(t, ok).IsFalse()
+
+	_, ok = cache.unknown["kid-1"]
+	test.That(t, ok).IsTrue()
+}
+
+func TestKeyCacheCachesFetchedVerifiers(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	fetches := 0
+	cache := NewKeyCache(func(kid string) (Verifier, error) {
+		fetches++
+		if kid != "key-1" {
+			return nil, ErrUnknownKeyID
+		}
+
+		return NewES256Verifier(&privateKey.PublicKey), nil
+	}, DefaultNegativeCacheTTL)
+
+	token := NewToken()
+	token.Header.KeyID = "key-1"
+	test.That(t, token.Sign(NewES256Signer(privateKey))).IsNil()
+
+	// Act.
+	err1 := cache.Verify(token)
+	err2 := cache.Verify(token)
+
+	// Assert.
+	test.That(t, err1).IsNil()
+	test.That(t, err2).IsNil()
+	test.That(t, fetches).IsEqualTo(1)
+}
+
+func TestKeyCacheDoesNotCacheFetchErrors(t *testing.T) {
+	// Arrange.
+	errUnavailable := errors.New("unavailable")
+
+	fetches := 0
+	cache := NewKeyCache(func(kid string) (Verifier, error) {
+		fetches++
+		return nil, errUnavailable
+	}, time.Minute)
+
+	// Act.
+	_, err1 := cache.VerifierFor("key-1")
+	_, err2 := cache.VerifierFor("key-1")
+
+	// Assert.
+	test.That(t, err1).IsEqualTo(errUnavailable)
+	test.That(t, err2).IsEqualTo(errUnavailable)
+	test.That(t, fetches).IsEqualTo(2)
+}
@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrUnknownKeyID is returned when a key identifier does not identify a known
+// key.
+var ErrUnknownKeyID = errors.New("the key identifier is unknown")
+
+// Keyring holds a set of keys, identified by key identifiers, one of which is
+// active and used to sign new tokens.  Tokens signed by any key in the keyring
+// can be verified by resolving their kid header, which allows keys to be rotated
+// without invalidating tokens signed by the previously active key.
+type Keyring struct {
+	mx     sync.RWMutex
+	keys   map[string]keyringEntry
+	active string
+}
+
+type keyringEntry struct {
+	signer   Signer
+	verifier Verifier
+}
+
+type keyringSigner struct {
+	Signer
+	kid string
+}
+
+var _ KeyIDSigner = &keyringSigner{}
+var _ ContextSigner = &keyringSigner{}
+
+// NewKeyring creates a new, empty Keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{
+		keys: map[string]keyringEntry{},
+	}
+}
+
+// Add adds a key to the keyring, replacing any key with the same identifier.
+// The signer may be nil for keys that are only used for verification.
+func (k *Keyring) Add(kid string, signer Signer, verifier Verifier) {
+	k.mx.Lock()
+	defer k.mx.Unlock()
+
+	k.keys[kid] = keyringEntry{
+		signer:   signer,
+		verifier: verifier,
+	}
+}
+
+// Remove removes a key from the keyring.  Tokens signed by the key can no longer
+// be verified.  Removing the active key leaves the keyring with no active key.
+func (k *Keyring) Remove(kid string) {
+	k.mx.Lock()
+	defer k.mx.Unlock()
+
+	delete(k.keys, kid)
+	if k.active == kid {
+		k.active = ""
+	}
+}
+
+// SetActive marks the key with the provided identifier as the one used to sign
+// new tokens.  The key must have a signer.
+func (k *Keyring) SetActive(kid string) error {
+	k.mx.Lock()
+	defer k.mx.Unlock()
+
+	entry, ok := k.keys[kid]
+	if !ok || entry.signer == nil {
+		return ErrUnknownKeyID
+	}
+
+	k.active = kid
+	return nil
+}
+
+// Active returns a Signer for the active key, or nil if there is no active key
+// or it has since been replaced by a key without a signer.  Tokens signed with it
+// have their kid header set to the key's identifier.
+func (k *Keyring) Active() Signer {
+	k.mx.RLock()
+	defer k.mx.RUnlock()
+
+	entry, ok := k.keys[k.active]
+	if !ok || entry.signer == nil {
+		return nil
+	}
+
+	return &keyringSigner{
+		Signer: entry.signer,
+		kid:    k.active,
+	}
+}
+
+// VerifierFor returns the Verifier for the key with the provided identifier.
+func (k *Keyring) VerifierFor(kid string) (Verifier, error) {
+	k.mx.RLock()
+	defer k.mx.RUnlock()
+
+	entry, ok := k.keys[kid]
+	if !ok || entry.verifier == nil {
+		return nil, ErrUnknownKeyID
+	}
+
+	return entry.verifier, nil
+}
+
+// Verify verifies the signature on the token using the key identified by its
+// kid header.
+func (k *Keyring) Verify(token *Token) error {
+	verifier, err := k.VerifierFor(token.Header.KeyID)
+	if err != nil {
+		return err
+	}
+
+	if !token.Verify(verifier) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (s *keyringSigner) KeyID() string {
+	return s.kid
+}
+
+func (s *keyringSigner) SignContext(ctx context.Context, b64HeaderAndBody string) ([]byte, error) {
+	if contextSigner, ok := s.Signer.(ContextSigner); ok {
+		return contextSigner.SignContext(ctx, b64HeaderAndBody)
+	}
+
+	return s.Sign(b64HeaderAndBody)
+}
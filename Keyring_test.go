@@ -0,0 +1,103 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestKeyringIssuesWithActiveKey(t *testing.T) {
+	// Arrange.
+	keyring := NewKeyring()
+	addTestKey(t, keyring, "key-1")
+
+	err := keyring.SetActive("key-1")
+	test.That(t, err).IsNil()
+
+	token := NewToken()
+
+	// Act.
+	err = token.Sign(keyring.Active())
+	test.That(t, err).IsNil()
+
+	// Assert.
+	test.That(t, token.HasKeyID("key-1")).IsTrue()
+	test.That(t, keyring.Verify(token)).IsNil()
+}
+
+func TestKeyringVerifiesAcrossRotation(t *testing.T) {
+	// Arrange.
+	keyring := NewKeyring()
+	addTestKey(t, keyring, "key-1")
+	addTestKey(t, keyring, "key-2")
+
+	err := keyring.SetActive("key-1")
+	test.That(t, err).IsNil()
+
+	token1 := NewToken()
+	err = token1.Sign(keyring.Active())
+	test.That(t, err).IsNil()
+
+	// Act.
+	err = keyring.SetActive("key-2")
+	test.That(t, err).IsNil()
+
+	token2 := NewToken()
+	err = token2.Sign(keyring.Active())
+	test.That(t, err).IsNil()
+
+	beforeRemovalErr1 := keyring.Verify(token1)
+	beforeRemovalErr2 := keyring.Verify(token2)
+
+	keyring.Remove("key-1")
+
+	afterRemovalErr1 := keyring.Verify(token1)
+	afterRemovalErr2 := keyring.Verify(token2)
+
+	// Assert.
+	test.That(t, token2.HasKeyID("key-2")).IsTrue()
+	test.That(t, beforeRemovalErr1).IsNil()
+	test.That(t, beforeRemovalErr2).IsNil()
+	test.That(t, afterRemovalErr1).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, afterRemovalErr2).IsNil()
+}
+
+func TestKeyringRejectsUnknownKeys(t *testing.T) {
+	// Arrange.
+	keyring := NewKeyring()
+
+	// Act.
+	setActiveErr := keyring.SetActive("key-1")
+	_, verifierErr := keyring.VerifierFor("key-1")
+
+	// Assert.
+	test.That(t, setActiveErr).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, verifierErr).IsEqualTo(ErrUnknownKeyID)
+	test.That(t, keyring.Active()).IsNil()
+}
+
+func TestKeyringActiveReplacedByVerificationOnlyKey(t *testing.T) {
+	// Arrange.
+	keyring := NewKeyring()
+	addTestKey(t, keyring, "key-1")
+	test.That(t, keyring.SetActive("key-1")).IsNil()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	// Act.
+	keyring.Add("key-1", nil, NewES256Verifier(&privateKey.PublicKey))
+
+	// Assert.
+	test.That(t, keyring.Active()).IsNil()
+}
+
+func addTestKey(t *testing.T, keyring *Keyring, kid string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	keyring.Add(kid, NewES256Signer(privateKey), NewES256Verifier(&privateKey.PublicKey))
+}
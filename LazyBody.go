@@ -0,0 +1,45 @@
+package jwt
+
+import "sync"
+
+// lazyBody holds the undecoded body segment of a token parsed with
+// WithLazyBody until the body is first accessed.
+type lazyBody struct {
+	once    sync.Once
+	segment string
+	config  *parseConfig
+	err     error
+}
+
+// DecodeBody decodes the body of a token parsed with WithLazyBody, returning
+// any error encountered.  The body is decoded at most once, so repeated calls
+// return the same error.  Callers that access the Body field directly, rather
+// than through the methods of Token, must call DecodeBody first.  Tokens that
+// were not parsed lazily always return nil.
+func (t *Token) DecodeBody() error {
+	t.ensureBody()
+
+	if t.lazy == nil {
+		return nil
+	}
+
+	return t.lazy.err
+}
+
+// ensureBody decodes a lazily parsed body on first access.  A body that fails
+// to decode is treated as empty.  It is safe to call concurrently.
+func (t *Token) ensureBody() {
+	if t.lazy == nil {
+		return
+	}
+
+	t.lazy.once.Do(func() {
+		body, err := decodeBody(t.lazy.segment, t.lazy.config)
+		if err != nil {
+			t.lazy.err = err
+			body = Body{}
+		}
+
+		t.Body = body
+	})
+}
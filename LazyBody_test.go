@@ -0,0 +1,158 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestLazyBodyMatchesEagerParse(t *testing.T) {
+	// Arrange.
+	tokenString := lazyBodyTestToken(t)
+
+	eager, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	// Act.
+	lazy, err := Parse(tokenString, WithLazyBody())
+	test.That(t, err).IsNil()
+
+	// Assert.
+	test.That(t, lazy.Body == nil).IsTrue()
+	test.That(t, lazy.Header.Algorithm).IsEqualTo(HS256)
+	test.That(t, lazy.Verify(NewHS256Verifier([]byte("secret")))).IsTrue()
+
+	sub, ok := lazy.GetStringClaim("sub")
+	test.That(t, ok).IsTrue()
+	test.That(t, sub).IsEqualTo("user-1")
+	test.That(t, lazy.HasScope("user:read")).IsTrue()
+	test.That(t, lazy.DecodeBody()).IsNil()
+	test.That(t, lazy.Equal(eager)).IsTrue()
+}
+
+func TestLazyBodyConcurrentFirstAccess(t *testing.T) {
+	// Arrange.
+	token, err := Parse(lazyBodyTestToken(t), WithLazyBody())
+	test.That(t, err).IsNil()
+
+	wg := sync.WaitGroup{}
+	results := make([]bool, 16)
+
+	// Act.
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, ok := token.GetClaim("sub")
+			results[i] = ok && token.HasScope("user:read")
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Assert.
+	for _, result := range results {
+		test.That(t, result).IsTrue()
+	}
+}
+
+func TestLazyBodyReportsDecodeErrors(t *testing.T) {
+	// Arrange.
+	spl := strings.Split(lazyBodyTestToken(t), ".")
+	spl[1] = base64.RawURLEncoding.EncodeToString([]byte(`[1,2,3]`))
+	tokenString := strings.Join(spl, ".")
+
+	_, eagerErr := Parse(tokenString)
+
+	// Act.
+	token, err := Parse(tokenString, WithLazyBody())
+	test.That(t, err).IsNil()
+
+	_, ok := token.GetClaim("sub")
+	decodeErr := token.DecodeBody()
+
+	// Assert.
+	test.That(t, eagerErr).IsEqualTo(ErrInvalidTokenBody)
+	test.That(t, ok).IsFalse()
+	test.That(t, decodeErr).IsEqualTo(ErrInvalidTokenBody)
+}
+
+func TestLazyBodyAppliesLimitsOnDecode(t *testing.T) {
+	// Arrange.
+	token, err := Parse(lazyBodyTestToken(t), WithLazyBody(), WithMaxClaims(1))
+	test.That(t, err).IsNil()
+
+	// Act.
+	err = token.DecodeBody()
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrTooManyClaims)
+}
+
+func TestLazyBodyDecodeErrorsFailValidation(t *testing.T) {
+	// Arrange.
+	secret := []byte("secret")
+	tokenString := signedTokenString(t, NewHS256Signer(secret), func(token *Token) {
+		token.AddClaim("sub", "user-1")
+		token.SetExpiry(time.Now().Add(-time.Hour))
+	})
+
+	parsed, err := Parse(tokenString, WithLazyBody(), WithMaxClaims(1))
+	test.That(t, err).IsNil()
+
+	validator := NewValidator(WithVerifier(NewHS256Verifier(secret)))
+
+	// Act.
+	err = validator.Validate(parsed)
+	errs := validator.ValidateAll(parsed)
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrTooManyClaims)
+	test.That(t, len(errs)).IsEqualTo(1)
+	test.That(t, errs[0]).IsEqualTo(ErrTooManyClaims)
+}
+
+func BenchmarkParseHeaderOnly(b *testing.B) {
+	token := NewToken()
+	token.AddClaim("sub", "user-1")
+	token.AddClaim("iss", "Test Issuer")
+	token.SetAudience("api", "web")
+	token.AddScope("user:read")
+	token.AddScope("user:create")
+
+	_ = token.Sign(NewHS256Signer([]byte("secret")))
+	tokenString, _ := token.Serialize()
+
+	benchmarks := []struct {
+		name string
+		opts []ParseOption
+	}{
+		{"Eager", nil},
+		{"Lazy", []ParseOption{WithLazyBody()}},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				parsed, err := Parse(tokenString, benchmark.opts...)
+				if err != nil || parsed.Header.Algorithm != HS256 {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func lazyBodyTestToken(t *testing.T) string {
+	return signedTokenString(t, NewHS256Signer([]byte("secret")), func(token *Token) {
+		token.AddClaim("sub", "user-1")
+		token.AddClaim("iss", "Test Issuer")
+		token.AddScope("user:read")
+	})
+}
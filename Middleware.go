@@ -0,0 +1,88 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type contextKey struct{}
+
+// Middleware returns HTTP middleware that authenticates requests using a bearer
+// token in their Authorization header.  The token must be signed by verifier,
+// not be expired and satisfy the provided options, otherwise the request is
+// rejected with 401 Unauthorized.  If verifier is nil, every request is
+// rejected.  Authenticated tokens are stored in the request context and can be
+// retrieved with FromContext.
+func Middleware(verifier Verifier, opts ...ValidateOption) func(http.Handler) http.Handler {
+	validator := NewValidator(append([]ValidateOption{WithVerifier(verifier)}, opts...)...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString, ok := bearerToken(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			token, err := Parse(tokenString)
+			if err != nil || verifier == nil || validator.Validate(token) != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), contextKey{}, token)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScopes returns HTTP middleware that requires the token stored in the
+// request context by Middleware to have all of the provided scopes.  Requests
+// whose token lacks a scope are rejected with 403 Forbidden and an
+// insufficient_scope challenge, and requests without a token are rejected with
+// 401 Unauthorized.  It must be applied inside Middleware.
+func RequireScopes(scopes ...string) func(http.Handler) http.Handler {
+	challenge := fmt.Sprintf(`Bearer error="insufficient_scope", scope="%v"`, ScopesToString(scopes))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := FromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			if !token.HasAllScopes(scopes...) {
+				w.Header().Set("WWW-Authenticate", challenge)
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// FromContext gets the token stored in the provided context by Middleware, if
+// present.
+func FromContext(ctx context.Context) (*Token, bool) {
+	token, ok := ctx.Value(contextKey{}).(*Token)
+	return token, ok
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	authorization := r.Header.Get("Authorization")
+
+	spl := strings.SplitN(authorization, " ", 2)
+	if len(spl) != 2 || !strings.EqualFold(spl[0], "Bearer") {
+		return "", false
+	}
+
+	tokenString := strings.TrimSpace(spl[1])
+	return tokenString, tokenString != ""
+}
@@ -0,0 +1,174 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestMiddleware(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey)
+	exp := time.Now().Add(time.Hour)
+
+	valid := signedTokenStringWithExpiry(t, signer, "Test Issuer", exp)
+	wrongIssuer := signedTokenStringWithExpiry(t, signer, "Other Issuer", exp)
+	expired := signedTokenStringWithExpiry(t, signer, "Test Issuer", time.Now().Add(-time.Minute))
+	wrongKey := signedTokenStringWithExpiry(t, NewES256Signer(otherKey), "Test Issuer", exp)
+
+	var seen *Token
+	handler := Middleware(NewES256Verifier(&privateKey.PublicKey), WithExpectedIssuer("Test Issuer"))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen, _ = FromContext(r.Context())
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	)
+
+	testCases := []struct {
+		authorization string
+		status        int
+	}{
+		{"Bearer " + valid, http.StatusNoContent},
+		{"bearer " + valid, http.StatusNoContent},
+		{"", http.StatusUnauthorized},
+		{"Bearer", http.StatusUnauthorized},
+		{"Bearer ", http.StatusUnauthorized},
+		{"Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"Bearer not.a.token", http.StatusUnauthorized},
+		{"Bearer garbage", http.StatusUnauthorized},
+		{"Bearer " + wrongIssuer, http.StatusUnauthorized},
+		{"Bearer " + expired, http.StatusUnauthorized},
+		{"Bearer " + wrongKey, http.StatusUnauthorized},
+	}
+
+	for _, testCase := range testCases {
+		seen = nil
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if testCase.authorization != "" {
+			r.Header.Set("Authorization", testCase.authorization)
+		}
+
+		w := httptest.NewRecorder()
+
+		// Act.
+		handler.ServeHTTP(w, r)
+
+		// Assert.
+		test.That(t, w.Code).IsEqualTo(testCase.status)
+
+		if testCase.status == http.StatusNoContent {
+			test.That(t, seen).IsNotNil()
+
+			iss, _ := seen.GetStringClaim("iss")
+			test.That(t, iss).IsEqualTo("Test Issuer")
+		} else {
+			test.That(t, seen).IsNil()
+			test.That(t, w.Header().Get("WWW-Authenticate")).IsNotEqualTo("")
+		}
+	}
+}
+
+func TestMiddlewareWithoutVerifierRejectsAll(t *testing.T) {
+	// Arrange.
+	tokenString := signedTokenStringWithExpiry(t, NewHS256Signer([]byte("secret")), "Test Issuer", time.Now().Add(time.Hour))
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+tokenString)
+
+	w := httptest.NewRecorder()
+
+	// Act.
+	handler.ServeHTTP(w, r)
+
+	// Assert.
+	test.That(t, w.Code).IsEqualTo(http.StatusUnauthorized)
+	test.That(t, w.Header().Get("WWW-Authenticate")).IsEqualTo(`Bearer error="invalid_token"`)
+}
+
+func TestRequireScopes(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	tokenString := signedTokenString(t, NewES256Signer(privateKey), func(token *Token) {
+		token.AddScope("user:read")
+		token.AddScope("user:create")
+	})
+
+	protect := Middleware(NewES256Verifier(&privateKey.PublicKey))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/users", protect(RequireScopes("user:read")(ok)))
+	mux.Handle("/admin", protect(RequireScopes("user:read", "admin:write")(ok)))
+
+	testCases := []struct {
+		path      string
+		status    int
+		challenge string
+	}{
+		{"/users", http.StatusNoContent, ""},
+		{"/admin", http.StatusForbidden, `Bearer error="insufficient_scope", scope="user:read admin:write"`},
+	}
+
+	for _, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+		r.Header.Set("Authorization", "Bearer "+tokenString)
+
+		w := httptest.NewRecorder()
+
+		// Act.
+		mux.ServeHTTP(w, r)
+
+		// Assert.
+		test.That(t, w.Code).IsEqualTo(testCase.status)
+		test.That(t, w.Header().Get("WWW-Authenticate")).IsEqualTo(testCase.challenge)
+	}
+}
+
+func TestRequireScopesWithoutMiddleware(t *testing.T) {
+	// Arrange.
+	handler := RequireScopes("user:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	// Act.
+	handler.ServeHTTP(w, r)
+
+	// Assert.
+	test.That(t, w.Code).IsEqualTo(http.StatusUnauthorized)
+}
+
+func TestFromContextMissing(t *testing.T) {
+	// Arrange.
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act.
+	token, ok := FromContext(r.Context())
+
+	// Assert.
+	test.That(t, ok).IsFalse()
+	test.That(t, token).IsNil()
+}
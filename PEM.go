@@ -0,0 +1,109 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+)
+
+// ErrInvalidPEM is returned when the provided data does not contain a PEM block.
+var ErrInvalidPEM = errors.New("the provided data does not contain a PEM block")
+
+// ErrUnexpectedKeyType is returned when a PEM block contains a key of a
+// different type to the one expected.
+var ErrUnexpectedKeyType = errors.New("the PEM block contains a key of an unexpected type")
+
+// LoadECDSAPrivateKeyPEM loads an ECDSA private key from a PEM block containing
+// either a SEC 1 or a PKCS #8 private key.
+func LoadECDSAPrivateKeyPEM(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err == nil {
+		return privateKey, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrUnexpectedKeyType
+	}
+
+	return privateKey, nil
+}
+
+// LoadECDSAPublicKeyPEM loads an ECDSA public key from a PEM block containing a
+// PKIX public key.
+func LoadECDSAPublicKeyPEM(data []byte) (*ecdsa.PublicKey, error) {
+	key, err := loadPublicKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, ErrUnexpectedKeyType
+	}
+
+	return publicKey, nil
+}
+
+// LoadRSAPrivateKeyPEM loads an RSA private key from a PEM block containing
+// either a PKCS #1 or a PKCS #8 private key.
+func LoadRSAPrivateKeyPEM(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err == nil {
+		return privateKey, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrUnexpectedKeyType
+	}
+
+	return privateKey, nil
+}
+
+// LoadRSAPublicKeyPEM loads an RSA public key from a PEM block containing a
+// PKIX public key.
+func LoadRSAPublicKeyPEM(data []byte) (*rsa.PublicKey, error) {
+	key, err := loadPublicKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrUnexpectedKeyType
+	}
+
+	return publicKey, nil
+}
+
+func loadPublicKeyPEM(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
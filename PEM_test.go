@@ -0,0 +1,89 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestLoadECDSAKeysPEM(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	rawPrivateKey, err := x509.MarshalECPrivateKey(privateKey)
+	test.That(t, err).IsNil()
+
+	rawPublicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	test.That(t, err).IsNil()
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawPrivateKey})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rawPublicKey})
+
+	// Act.
+	loadedPrivateKey, privateErr := LoadECDSAPrivateKeyPEM(privatePEM)
+	loadedPublicKey, publicErr := LoadECDSAPublicKeyPEM(publicPEM)
+
+	// Assert.
+	test.That(t, privateErr).IsNil()
+	test.That(t, publicErr).IsNil()
+	test.That(t, loadedPrivateKey.D.Cmp(privateKey.D)).IsEqualTo(0)
+	test.That(t, loadedPublicKey.X.Cmp(privateKey.X)).IsEqualTo(0)
+	test.That(t, loadedPublicKey.Y.Cmp(privateKey.Y)).IsEqualTo(0)
+}
+
+func TestLoadRSAKeysPEM(t *testing.T) {
+	// Arrange.
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.That(t, err).IsNil()
+
+	rawPrivateKey, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	test.That(t, err).IsNil()
+
+	rawPublicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	test.That(t, err).IsNil()
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rawPrivateKey})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rawPublicKey})
+
+	// Act.
+	loadedPrivateKey, privateErr := LoadRSAPrivateKeyPEM(privatePEM)
+	loadedPublicKey, publicErr := LoadRSAPublicKeyPEM(publicPEM)
+
+	// Assert.
+	test.That(t, privateErr).IsNil()
+	test.That(t, publicErr).IsNil()
+	test.That(t, loadedPrivateKey.D.Cmp(privateKey.D)).IsEqualTo(0)
+	test.That(t, loadedPublicKey.N.Cmp(privateKey.N)).IsEqualTo(0)
+}
+
+func TestLoadKeysPEMMismatchedType(t *testing.T) {
+	// Arrange.
+	ecPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	rawPrivateKey, err := x509.MarshalPKCS8PrivateKey(ecPrivateKey)
+	test.That(t, err).IsNil()
+
+	rawPublicKey, err := x509.MarshalPKIXPublicKey(&ecPrivateKey.PublicKey)
+	test.That(t, err).IsNil()
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rawPrivateKey})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rawPublicKey})
+
+	// Act.
+	_, privateErr := LoadRSAPrivateKeyPEM(privatePEM)
+	_, publicErr := LoadRSAPublicKeyPEM(publicPEM)
+	_, invalidErr := LoadECDSAPublicKeyPEM([]byte("not a pem block"))
+
+	// Assert.
+	test.That(t, privateErr).IsEqualTo(ErrUnexpectedKeyType)
+	test.That(t, publicErr).IsEqualTo(ErrUnexpectedKeyType)
+	test.That(t, invalidErr).IsEqualTo(ErrInvalidPEM)
+}
@@ -0,0 +1,152 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrTooManyClaims is returned when a parsed token has more claims than allowed.
+var ErrTooManyClaims = errors.New("the token has too many claims")
+
+// ErrTooManyScopes is returned when a parsed token has more scopes than allowed.
+var ErrTooManyScopes = errors.New("the token has too many scopes")
+
+// ErrMissingAlgorithm is returned by ParseStrict when the header of a token has
+// no alg parameter, or it is empty.
+var ErrMissingAlgorithm = errors.New("the token header does not specify an algorithm")
+
+// ErrUnsupportedCriticalParam is returned by ParseStrict when the crit header of
+// a token is malformed or lists a parameter that is not understood.
+var ErrUnsupportedCriticalParam = errors.New("the token header has an unsupported critical parameter")
+
+// understoodCriticalParams holds the extension header parameters that the
+// package processes, and so may be listed in crit.  There are currently none.
+var understoodCriticalParams = map[string]bool{}
+
+// ParseOption configures how a token is parsed.
+type ParseOption func(c *parseConfig)
+
+type parseConfig struct {
+	maxClaims int
+	maxScopes int
+	strict    bool
+	lazy      bool
+}
+
+func newParseConfig(opts []ParseOption) *parseConfig {
+	c := &parseConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithMaxClaims limits the number of claims, including scope, that a parsed
+// token may have.
+func WithMaxClaims(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.maxClaims = n
+	}
+}
+
+// WithMaxScopes limits the number of scopes that a parsed token may have.
+func WithMaxScopes(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.maxScopes = n
+	}
+}
+
+// WithLazyBody defers decoding the body of a parsed token until it is first
+// accessed, which avoids the cost for callers that only need the header or
+// signature.  Errors decoding the body, including exceeding the claim and scope
+// limits, are reported by DecodeBody rather than by Parse.
+func WithLazyBody() ParseOption {
+	return func(c *parseConfig) {
+		c.lazy = true
+	}
+}
+
+func strictParse(c *parseConfig) {
+	c.strict = true
+}
+
+func (c *parseConfig) decodeSegment(segment string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err == nil || c.strict {
+		return raw, err
+	}
+
+	encodings := []*base64.Encoding{base64.URLEncoding, base64.StdEncoding, base64.RawStdEncoding}
+	for _, encoding := range encodings {
+		raw, fallbackErr := encoding.DecodeString(segment)
+		if fallbackErr == nil {
+			return raw, nil
+		}
+	}
+
+	return nil, err
+}
+
+func (c *parseConfig) checkLimits(t *Token) error {
+	if c.maxClaims > 0 && len(t.Body) > c.maxClaims {
+		return ErrTooManyClaims
+	}
+
+	if c.maxScopes > 0 && len(t.GetScopes()) > c.maxScopes {
+		return ErrTooManyScopes
+	}
+
+	return nil
+}
+
+// checkHeader applies the strict header checks: alg must be present and
+// recognized, typ must be a string when present, and crit must only list
+// understood parameters.
+func (c *parseConfig) checkHeader(rawHeader []byte) error {
+	if !c.strict {
+		return nil
+	}
+
+	params := map[string]interface{}{}
+	err := json.Unmarshal(rawHeader, &params)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal header: %w", err)
+	}
+
+	alg, _ := params["alg"].(string)
+	if alg == "" {
+		return ErrMissingAlgorithm
+	}
+
+	if !isRecognizedAlgorithm(Algorithm(alg)) {
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, alg)
+	}
+
+	if typ, ok := params["typ"]; ok {
+		if _, isString := typ.(string); !isString {
+			return fmt.Errorf("%w: typ must be a string", ErrInvalidType)
+		}
+	}
+
+	crit, ok := params["crit"]
+	if !ok {
+		return nil
+	}
+
+	names, ok := crit.([]interface{})
+	if !ok || len(names) == 0 {
+		return fmt.Errorf("%w: crit must be a non-empty array", ErrUnsupportedCriticalParam)
+	}
+
+	for _, name := range names {
+		str, isString := name.(string)
+		if !isString || !understoodCriticalParams[str] {
+			return fmt.Errorf("%w: %v", ErrUnsupportedCriticalParam, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestParseWithMaxClaims(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddClaim("iss", "Test Issuer")
+	token.AddClaim("sub", "user-1")
+	token.AddScope("user:read")
+
+	tokenString, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	// Act.
+	_, withinErr := Parse(tokenString, WithMaxClaims(3))
+	_, exceededErr := Parse(tokenString, WithMaxClaims(2))
+
+	// Assert.
+	test.That(t, withinErr).IsNil()
+	test.That(t, exceededErr).IsEqualTo(ErrTooManyClaims)
+}
+
+func TestParseWithMaxScopes(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddScope("user:read")
+	token.AddScope("user:create")
+	token.AddScope("user:delete")
+
+	tokenString, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	// Act.
+	_, withinErr := Parse(tokenString, WithMaxScopes(3))
+	_, exceededErr := Parse(tokenString, WithMaxScopes(2))
+
+	// Assert.
+	test.That(t, withinErr).IsNil()
+	test.That(t, exceededErr).IsEqualTo(ErrTooManyScopes)
+}
+
+func TestParseTolerantBase64(t *testing.T) {
+	// Arrange.
+	secret := []byte("an example shared secret for hs256")
+
+	header := base64.URLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	body := base64.StdEncoding.EncodeToString([]byte(`{"iss":"Legacy Partner ~~~"}`))
+	b64HeaderAndBody := header + "." + body
+
+	signature, err := NewHS256Signer(secret).Sign(b64HeaderAndBody)
+	test.That(t, err).IsNil()
+
+	tokenString := b64HeaderAndBody + "." + base64.URLEncoding.EncodeToString(signature)
+
+	// Act.
+	tolerantToken, tolerantErr := Parse(tokenString)
+	_, strictErr := ParseStrict(tokenString)
+
+	// Assert.
+	test.That(t, strings.Contains(tokenString, "=")).IsTrue()
+	test.That(t, tolerantErr).IsNil()
+	test.That(t, tolerantToken.Verify(NewHS256Verifier(secret))).IsTrue()
+
+	iss, _ := tolerantToken.GetStringClaim("iss")
+	test.That(t, iss).IsEqualTo("Legacy Partner ~~~")
+
+	var corruptErr base64.CorruptInputError
+	test.That(t, errors.As(strictErr, &corruptErr)).IsTrue()
+}
+
+func TestParseStrictAcceptsConformantTokens(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddClaim("iss", "Test Issuer")
+
+	tokenString, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	// Act.
+	_, err = ParseStrict(tokenString)
+
+	// Assert.
+	test.That(t, err).IsNil()
+}
+
+func TestParseStrictRejectsInvalidHeaders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expected error
+	}{
+		{"missing alg", `{"typ":"JWT"}`, ErrMissingAlgorithm},
+		{"empty alg", `{"alg":"","typ":"JWT"}`, ErrMissingAlgorithm},
+		{"non-string alg", `{"alg":256,"typ":"JWT"}`, ErrMissingAlgorithm},
+		{"unknown alg", `{"alg":"XX999","typ":"JWT"}`, ErrUnsupportedAlgorithm},
+		{"non-string typ", `{"alg":"HS256","typ":1}`, ErrInvalidType},
+		{"unsupported crit", `{"alg":"HS256","typ":"JWT","crit":["exp"],"exp":1}`, ErrUnsupportedCriticalParam},
+		{"empty crit", `{"alg":"HS256","typ":"JWT","crit":[]}`, ErrUnsupportedCriticalParam},
+		{"non-array crit", `{"alg":"HS256","typ":"JWT","crit":"exp"}`, ErrUnsupportedCriticalParam},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange.
+			header := base64.RawURLEncoding.EncodeToString([]byte(testCase.header))
+			body := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"Test Issuer"}`))
+			tokenString := header + "." + body + ".c2ln"
+
+			// Act.
+			_, err := ParseStrict(tokenString)
+
+			// Assert.
+			test.That(t, errors.Is(err, testCase.expected)).IsTrue()
+		})
+	}
+}
+
+func TestParseStrictAcceptsHeaderWithoutType(t *testing.T) {
+	// Arrange.
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"Test Issuer"}`))
+
+	// Act.
+	_, err := ParseStrict(header + "." + body + ".c2ln")
+
+	// Assert.
+	test.That(t, err).IsNil()
+}
+
+func TestParseDoesNotCheckHeaders(t *testing.T) {
+	// Arrange.
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"crit":["exp"]}`))
+	body := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"Test Issuer"}`))
+
+	// Act.
+	token, err := Parse(header + "." + body + ".c2ln")
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, token.Header.Algorithm).IsEqualTo(Algorithm(""))
+}
+
+func TestParseStrictDoesNotModifyCallerOptions(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddClaim("iss", "Test Issuer")
+
+	tokenString, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	opts := make([]ParseOption, 1, 2)
+	opts[0] = WithMaxClaims(10)
+	backing := opts[:2]
+
+	// Act.
+	_, err = ParseStrict(tokenString, opts...)
+
+	// Assert.
+	test.That(t, err).IsNil()
+	test.That(t, backing[1] == nil).IsTrue()
+}
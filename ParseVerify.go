@@ -0,0 +1,82 @@
+package jwt
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrIssuerNotAllowed is returned when the issuer of a token is not one of the
+// allowed issuers.
+var ErrIssuerNotAllowed = errors.New("the token issuer is not allowed")
+
+// ErrNoValidToken is returned when none of the provided tokens are valid.
+var ErrNoValidToken = errors.New("none of the provided tokens are valid")
+
+// ParseVerifyAcceptTypes parses the provided string token, verifies its
+// signature and checks that the type in its header is one of the provided
+// types.  The types are compared case-insensitively, and the matching entry from
+// types is returned alongside the token so that callers can remap between
+// token formats.
+func ParseVerifyAcceptTypes(tokenString string, verifier Verifier, types ...string) (*Token, string, error) {
+	token, err := Parse(tokenString)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !token.Verify(verifier) {
+		return nil, "", ErrInvalidSignature
+	}
+
+	for _, typ := range types {
+		if strings.EqualFold(token.Header.Type, typ) {
+			return token, typ, nil
+		}
+	}
+
+	return nil, "", ErrInvalidType
+}
+
+// ParseVerifyIssuer parses the provided string token, verifies its signature and
+// checks that its issuer is one of the allowed issuers, returning the issuer
+// that matched alongside the token.
+func ParseVerifyIssuer(tokenString string, verifier Verifier, allowed ...string) (*Token, string, error) {
+	token, err := Parse(tokenString)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !token.Verify(verifier) {
+		return nil, "", ErrInvalidSignature
+	}
+
+	iss, ok := token.GetStringClaim("iss")
+	if !ok {
+		return nil, "", ErrIssuerNotAllowed
+	}
+
+	for _, v := range allowed {
+		if v == iss {
+			return token, iss, nil
+		}
+	}
+
+	return nil, "", ErrIssuerNotAllowed
+}
+
+// FirstValid returns the first of the provided string tokens that parses,
+// verifies against the provided verifier and is not expired at now.
+func FirstValid(tokenStrings []string, verifier Verifier, now time.Time) (*Token, error) {
+	for _, tokenString := range tokenStrings {
+		token, err := Parse(tokenString)
+		if err != nil {
+			continue
+		}
+
+		if token.Verify(verifier) && !token.IsExpired(now) {
+			return token, nil
+		}
+	}
+
+	return nil, ErrNoValidToken
+}
@@ -0,0 +1,151 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestParseVerifyAcceptTypes(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey)
+	verifier := NewES256Verifier(&privateKey.PublicKey)
+
+	jwtToken := signedTokenStringWithType(t, signer, "JWT")
+	atToken := signedTokenStringWithType(t, signer, "at+jwt")
+	fooToken := signedTokenStringWithType(t, signer, "foo")
+
+	// Act.
+	_, jwtType, jwtErr := ParseVerifyAcceptTypes(jwtToken, verifier, "JWT", "at+jwt")
+	_, atType, atErr := ParseVerifyAcceptTypes(atToken, verifier, "JWT", "AT+JWT")
+	fooResult, fooType, fooErr := ParseVerifyAcceptTypes(fooToken, verifier, "JWT", "at+jwt")
+
+	// Assert.
+	test.That(t, jwtErr).IsNil()
+	test.That(t, jwtType).IsEqualTo("JWT")
+	test.That(t, atErr).IsNil()
+	test.That(t, atType).IsEqualTo("AT+JWT")
+	test.That(t, fooErr).IsEqualTo(ErrInvalidType)
+	test.That(t, fooType).IsEqualTo("")
+	test.That(t, fooResult).IsNil()
+}
+
+func TestParseVerifyAcceptTypesInvalidSignature(t *testing.T) {
+	// Arrange.
+	privateKey1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	privateKey2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	tokenString := signedTokenStringWithType(t, NewES256Signer(privateKey1), "JWT")
+
+	// Act.
+	_, _, err = ParseVerifyAcceptTypes(tokenString, NewES256Verifier(&privateKey2.PublicKey), "JWT")
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrInvalidSignature)
+}
+
+func signedTokenString(t *testing.T, signer Signer, build func(token *Token)) string {
+	token := NewToken()
+	build(token)
+
+	err := token.Sign(signer)
+	test.That(t, err).IsNil()
+
+	tokenString, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	return tokenString
+}
+
+func signedTokenStringWithType(t *testing.T, signer Signer, typ string) string {
+	return signedTokenString(t, signer, func(token *Token) {
+		token.Header.Type = typ
+		token.AddClaim("iss", "Test Issuer")
+	})
+}
+
+func TestFirstValid(t *testing.T) {
+	// Arrange.
+	privateKey1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	privateKey2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey1)
+	verifier := NewES256Verifier(&privateKey1.PublicKey)
+	now := time.Now()
+
+	expired := signedTokenStringWithExpiry(t, signer, "expired", now.Add(-time.Minute))
+	invalid := signedTokenStringWithExpiry(t, NewES256Signer(privateKey2), "invalid", now.Add(time.Hour))
+	valid := signedTokenStringWithExpiry(t, signer, "valid", now.Add(time.Hour))
+
+	// Act.
+	token, err := FirstValid([]string{"malformed", expired, invalid, valid}, verifier, now)
+
+	// Assert.
+	test.That(t, err).IsNil()
+
+	iss, ok := token.GetStringClaim("iss")
+	test.That(t, ok).IsTrue()
+	test.That(t, iss).IsEqualTo("valid")
+}
+
+func TestFirstValidNoneValid(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey)
+	verifier := NewES256Verifier(&privateKey.PublicKey)
+	now := time.Now()
+
+	expired := signedTokenStringWithExpiry(t, signer, "expired", now.Add(-time.Minute))
+
+	// Act.
+	token, err := FirstValid([]string{expired, "malformed"}, verifier, now)
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrNoValidToken)
+	test.That(t, token).IsNil()
+}
+
+func signedTokenStringWithExpiry(t *testing.T, signer Signer, iss string, exp time.Time) string {
+	return signedTokenString(t, signer, func(token *Token) {
+		token.AddClaim("iss", iss)
+		token.SetExpiry(exp)
+	})
+}
+
+func TestParseVerifyIssuer(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey)
+	verifier := NewES256Verifier(&privateKey.PublicKey)
+	exp := time.Now().Add(time.Hour)
+
+	allowedToken := signedTokenStringWithExpiry(t, signer, "https://b.example.com", exp)
+	disallowedToken := signedTokenStringWithExpiry(t, signer, "https://evil.example.com", exp)
+
+	// Act.
+	token, iss, allowedErr := ParseVerifyIssuer(allowedToken, verifier, "https://a.example.com", "https://b.example.com")
+	_, _, disallowedErr := ParseVerifyIssuer(disallowedToken, verifier, "https://a.example.com", "https://b.example.com")
+
+	// Assert.
+	test.That(t, allowedErr).IsNil()
+	test.That(t, token).IsNotNil()
+	test.That(t, iss).IsEqualTo("https://b.example.com")
+	test.That(t, disallowedErr).IsEqualTo(ErrIssuerNotAllowed)
+}
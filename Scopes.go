@@ -0,0 +1,53 @@
+package jwt
+
+import "strings"
+
+// ScopesToString converts a list of scopes to the space-delimited string form
+// used by OAuth2.
+func ScopesToString(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// ScopesFromString converts a space-delimited string of scopes, as used by
+// OAuth2, to a list of scopes.  Runs of whitespace are treated as a single
+// delimiter, and an empty list is returned for an empty string.
+func ScopesFromString(scopes string) []string {
+	return strings.Fields(scopes)
+}
+
+// ScopeMatches returns true if the scope matches the provided colon-delimited
+// pattern, using the same rules as Token.HasScopeMatching.
+func ScopeMatches(pattern string, scope string) bool {
+	patternSegments := strings.Split(pattern, ":")
+	scopeSegments := strings.Split(scope, ":")
+
+	for i, patternSegment := range patternSegments {
+		if patternSegment == "**" && i == len(patternSegments)-1 {
+			return len(scopeSegments) > i && !hasEmptySegment(scopeSegments[i:])
+		}
+
+		if i >= len(scopeSegments) {
+			return false
+		}
+
+		if patternSegment == "*" && scopeSegments[i] != "" {
+			continue
+		}
+
+		if patternSegment != scopeSegments[i] {
+			return false
+		}
+	}
+
+	return len(patternSegments) == len(scopeSegments)
+}
+
+func hasEmptySegment(segments []string) bool {
+	for _, segment := range segments {
+		if segment == "" {
+			return true
+		}
+	}
+
+	return false
+}
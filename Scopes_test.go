@@ -0,0 +1,86 @@
+package jwt
+
+import (
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestScopesStringRoundTrip(t *testing.T) {
+	// Arrange.
+	scopes := []string{"user:read", "user:create", "admin:write"}
+
+	// Act.
+	str := ScopesToString(scopes)
+	roundTripped := ScopesFromString(str)
+
+	// Assert.
+	test.That(t, str).IsEqualTo("user:read user:create admin:write")
+	test.That(t, roundTripped).HasEquivalentSequenceTo(scopes)
+}
+
+func TestScopesFromStringMultipleSpaces(t *testing.T) {
+	// Arrange.
+	str := "  user:read   user:create\tadmin:write "
+
+	// Act.
+	scopes := ScopesFromString(str)
+
+	// Assert.
+	test.That(t, scopes).HasEquivalentSequenceTo([]string{"user:read", "user:create", "admin:write"})
+	test.That(t, ScopesToString(scopes)).IsEqualTo("user:read user:create admin:write")
+}
+
+func TestScopesStringEmpty(t *testing.T) {
+	// Arrange.
+	var scopes []string
+
+	// Act.
+	str := ScopesToString(scopes)
+	roundTripped := ScopesFromString(str)
+
+	// Assert.
+	test.That(t, str).IsEqualTo("")
+	test.That(t, roundTripped == nil).IsFalse()
+	test.That(t, len(roundTripped)).IsEqualTo(0)
+}
+
+func TestScopeMatches(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		scope    string
+		expected bool
+	}{
+		{"user:read", "user:read", true},
+		{"user:read", "user:create", false},
+		{"user:*", "user:create", true},
+		{"user:*", "user", false},
+		{"user:*", "user:billing:read", false},
+		{"*:read", "admin:read", true},
+		{"admin:**", "admin:billing:read", true},
+		{"admin:**", "admin:billing", true},
+		{"admin:**", "admin", false},
+		{"**", "admin:billing:read", true},
+		{"**:read", "admin:read", false},
+		{"**:read", "**:read", true},
+		{"*", "*", true},
+		{"*", "user:read", false},
+		{"user:*", "user:*", true},
+		{"user:read", "user:*", false},
+		{"user:*", "user:", false},
+		{"user::read", "user::read", true},
+		{"user:*:read", "user::read", false},
+		{"admin:**", "admin:billing:", false},
+		{"user:", "user:", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.pattern+" against "+testCase.scope, func(t *testing.T) {
+			// Act.
+			matches := ScopeMatches(testCase.pattern, testCase.scope)
+
+			// Assert.
+			test.That(t, matches).IsEqualTo(testCase.expected)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package jwt
+
+import (
+	"errors"
+	"math"
+)
+
+// MinSecretEntropyBits is the minimum estimated entropy, in bits, that
+// ValidateSecretEntropy requires of an HMAC secret.
+const MinSecretEntropyBits = 128
+
+// ErrLowEntropySecret is returned when an HMAC secret is estimated to have too
+// little entropy to be used safely.
+var ErrLowEntropySecret = errors.New("the secret has too little entropy")
+
+// ValidateSecretEntropy estimates the entropy of the provided HMAC secret using
+// its Shannon entropy per byte multiplied by its length, and returns
+// ErrLowEntropySecret if the estimate is below MinSecretEntropyBits.  This is a
+// heuristic that catches short or repetitive secrets such as passwords; it
+// cannot prove that a secret was generated randomly.
+func ValidateSecretEntropy(secret []byte) error {
+	if len(secret) == 0 {
+		return ErrLowEntropySecret
+	}
+
+	counts := [256]int{}
+	for _, b := range secret {
+		counts[b]++
+	}
+
+	n := float64(len(secret))
+	entropyPerByte := 0.0
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		p := float64(count) / n
+		entropyPerByte -= p * math.Log2(p)
+	}
+
+	if entropyPerByte*n < MinSecretEntropyBits {
+		return ErrLowEntropySecret
+	}
+
+	return nil
+}
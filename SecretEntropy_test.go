@@ -0,0 +1,38 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestValidateSecretEntropyLow(t *testing.T) {
+	// Arrange.
+	secrets := [][]byte{
+		[]byte("password123"),
+		[]byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+		{},
+	}
+
+	for _, secret := range secrets {
+		// Act.
+		err := ValidateSecretEntropy(secret)
+
+		// Assert.
+		test.That(t, err).IsEqualTo(ErrLowEntropySecret)
+	}
+}
+
+func TestValidateSecretEntropyRandom(t *testing.T) {
+	// Arrange.
+	secret := make([]byte, 64)
+	_, err := rand.Read(secret)
+	test.That(t, err).IsNil()
+
+	// Act.
+	err = ValidateSecretEntropy(secret)
+
+	// Assert.
+	test.That(t, err).IsNil()
+}
@@ -0,0 +1,10 @@
+package jwt
+
+import "crypto/subtle"
+
+// SecureCompare returns true if the two provided strings are equal.  The
+// comparison is performed in constant time with respect to their contents so
+// that comparing token strings does not leak timing information.
+func SecureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
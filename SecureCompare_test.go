@@ -0,0 +1,34 @@
+package jwt
+
+import (
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestSecureCompareEqual(t *testing.T) {
+	// Arrange.
+	a := "eyJhbGciOiJFUzI1NiJ9.eyJpc3MiOiJ4In0.c2ln"
+	b := "eyJhbGciOiJFUzI1NiJ9.eyJpc3MiOiJ4In0.c2ln"
+
+	// Act.
+	equal := SecureCompare(a, b)
+
+	// Assert.
+	test.That(t, equal).IsTrue()
+}
+
+func TestSecureCompareNotEqual(t *testing.T) {
+	// Arrange.
+	a := "eyJhbGciOiJFUzI1NiJ9.eyJpc3MiOiJ4In0.c2ln"
+	b := "eyJhbGciOiJFUzI1NiJ9.eyJpc3MiOiJ5In0.c2ln"
+	c := "eyJhbGciOiJFUzI1NiJ9"
+
+	// Act.
+	equalB := SecureCompare(a, b)
+	equalC := SecureCompare(a, c)
+
+	// Assert.
+	test.That(t, equalB).IsFalse()
+	test.That(t, equalC).IsFalse()
+}
@@ -0,0 +1,39 @@
+package jwt
+
+import "time"
+
+// SignOption configures how a token is signed by SignWith.
+type SignOption func(c *signConfig)
+
+type signConfig struct {
+	clock     func() time.Time
+	notBefore bool
+}
+
+func newSignConfig(opts []SignOption) *signConfig {
+	c := &signConfig{
+		clock: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithNotBefore sets the nbf claim of the token to the time it is signed, unless
+// the claim is already present.
+func WithNotBefore() SignOption {
+	return func(c *signConfig) {
+		c.notBefore = true
+	}
+}
+
+// WithSigningClock sets the clock used to determine the time a token is signed,
+// which otherwise defaults to time.Now.
+func WithSigningClock(clock func() time.Time) SignOption {
+	return func(c *signConfig) {
+		c.clock = clock
+	}
+}
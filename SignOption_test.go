@@ -0,0 +1,105 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestSignWithSetsIssuedAt(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Unix(1600000000, 0)
+	clock := func() time.Time { return now }
+
+	token1 := NewToken()
+
+	// Act.
+	err = token1.SignWith(NewES256Signer(privateKey), WithSigningClock(clock))
+	test.That(t, err).IsNil()
+
+	tokenString, err := token1.Serialize()
+	test.That(t, err).IsNil()
+
+	token2, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	// Assert.
+	iat, ok := token2.GetClaim("iat")
+	test.That(t, ok).IsTrue()
+	test.That(t, iat).IsEqualTo(float64(1600000000))
+
+	_, ok = token2.GetClaim("nbf")
+	test.That(t, ok).IsFalse()
+
+	test.That(t, token2.Verify(NewES256Verifier(&privateKey.PublicKey))).IsTrue()
+}
+
+func TestSignWithNotBefore(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Unix(1600000000, 0)
+	clock := func() time.Time { return now }
+
+	token := NewToken()
+
+	// Act.
+	err = token.SignWith(NewES256Signer(privateKey), WithNotBefore(), WithSigningClock(clock))
+
+	// Assert.
+	test.That(t, err).IsNil()
+
+	nbf, ok := token.GetNotBefore()
+	test.That(t, ok).IsTrue()
+	test.That(t, nbf.Equal(now)).IsTrue()
+}
+
+func TestSignWithRespectsExplicitClaims(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	clock := func() time.Time { return time.Unix(1600000000, 0) }
+
+	token := NewToken()
+	token.AddClaim("iat", 1500000000)
+	token.AddClaim("nbf", 1500000001)
+
+	// Act.
+	err = token.SignWith(NewES256Signer(privateKey), WithNotBefore(), WithSigningClock(clock))
+
+	// Assert.
+	test.That(t, err).IsNil()
+
+	iat, _ := token.GetClaim("iat")
+	test.That(t, iat).IsEqualTo(1500000000)
+
+	nbf, _ := token.GetClaim("nbf")
+	test.That(t, nbf).IsEqualTo(1500000001)
+}
+
+func TestSignWithFailureLeavesClaimsUnchanged(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	signer := NewES256Signer(privateKey)
+	signer.Zeroize()
+
+	token := NewToken()
+
+	// Act.
+	err = token.SignWith(signer, WithNotBefore())
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrSignerZeroized)
+	test.That(t, len(token.Body)).IsEqualTo(0)
+}
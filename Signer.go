@@ -1,7 +1,25 @@
 package jwt
 
+import "context"
+
 // Signer defines the methods that any JWT signer must implement.
 type Signer interface {
 	Algorithm() Algorithm
 	Sign(b64HeaderAndBody string) ([]byte, error)
 }
+
+// ContextSigner defines the methods that a JWT signer whose signing operation
+// should be cancellable, such as one backed by a remote KMS or HSM, must
+// implement.
+type ContextSigner interface {
+	Signer
+	SignContext(ctx context.Context, b64HeaderAndBody string) ([]byte, error)
+}
+
+// KeyIDSigner defines the methods that a JWT signer which identifies its key
+// must implement.  Tokens signed with a KeyIDSigner have the kid header set to
+// the identifier of the key.
+type KeyIDSigner interface {
+	Signer
+	KeyID() string
+}
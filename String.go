@@ -0,0 +1,47 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// String renders the header and body of the token as indented JSON, followed by
+// a summary of its signature in place of the signature itself.
+func (t *Token) String() string {
+	return t.DebugString()
+}
+
+// DebugString renders the token in the same way as String, but with the values
+// of the named claims masked.  The token is not modified.
+func (t *Token) DebugString(redact ...string) string {
+	t.ensureBody()
+
+	body := make(Body, len(t.Body))
+	for k, v := range t.Body {
+		body[k] = v
+	}
+
+	for _, name := range redact {
+		if _, ok := body[name]; ok {
+			body[name] = "[REDACTED]"
+		}
+	}
+
+	rawHeader, err := json.MarshalIndent(t.Header, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("<invalid token: %v>", err)
+	}
+
+	rawBody, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("<invalid token: %v>", err)
+	}
+
+	signature := "unsigned"
+	if t.IsSigned() {
+		signature = fmt.Sprintf("%v sig (%v bytes)", t.Header.Algorithm, len(t.Signature))
+	}
+
+	return strings.Join([]string{string(rawHeader), string(rawBody), signature}, "\n")
+}
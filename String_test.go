@@ -0,0 +1,57 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestTokenString(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	token := NewToken()
+	token.AddClaim("iss", "Test Issuer")
+
+	err = token.Sign(NewES256Signer(privateKey))
+	test.That(t, err).IsNil()
+
+	// Act.
+	str := token.String()
+
+	// Assert.
+	expected := `{
+  "alg": "ES256",
+  "typ": "JWT"
+}
+{
+  "iss": "Test Issuer"
+}
+ES256 sig (64 bytes)`
+
+	test.That(t, str).IsEqualTo(expected)
+}
+
+func TestTokenDebugStringRedactsClaims(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddClaim("iss", "Test Issuer")
+	token.AddClaim("password", "hunter2")
+
+	// Act.
+	str := token.DebugString("password", "missing")
+
+	// Assert.
+	test.That(t, strings.Contains(str, `"password": "[REDACTED]"`)).IsTrue()
+	test.That(t, strings.Contains(str, "hunter2")).IsFalse()
+	test.That(t, strings.Contains(str, "missing")).IsFalse()
+	test.That(t, strings.HasSuffix(str, "\nunsigned")).IsTrue()
+
+	password, _ := token.GetStringClaim("password")
+	test.That(t, password).IsEqualTo("hunter2")
+}
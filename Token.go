@@ -1,11 +1,18 @@
 package jwt
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Token represents a (potentially signed) JWT token.
@@ -13,16 +20,50 @@ type Token struct {
 	Header    Header
 	Body      Body
 	Signature []byte
+
+	rawHeaderAndBody string
+	stringScopes     bool
+	sortedScopes     bool
+	lazy             *lazyBody
 }
 
 // ErrInvalidTokenStructure is returned when the provided token has an invalid
 // structure and is not semantically a JWT.
 var ErrInvalidTokenStructure = errors.New("the provided token is invalid")
 
+// ErrWrongSegmentCount is returned when the provided token does not have
+// exactly three dot-separated segments.  It wraps ErrInvalidTokenStructure.
+var ErrWrongSegmentCount = fmt.Errorf("%w: wrong number of segments", ErrInvalidTokenStructure)
+
+// ErrEmptySegment is returned when the header or body segment of the provided
+// token is empty, or the signature segment is empty for a token with an
+// algorithm other than none.  It wraps ErrInvalidTokenStructure.
+var ErrEmptySegment = fmt.Errorf("%w: empty segment", ErrInvalidTokenStructure)
+
+// ErrInvalidTokenBody is returned when the body of the provided token is not a
+// JSON object.
+var ErrInvalidTokenBody = errors.New("the provided token body is not a JSON object")
+
 // ErrImmutable is returned when an operation cannot be completed due to the
 // token being signed.
 var ErrImmutable = errors.New("the operation cannot complete as the token is immutable")
 
+// ErrInvalidSignature is returned when the signature on a token could not be
+// verified.
+var ErrInvalidSignature = errors.New("the signature on the token is invalid")
+
+// ErrInvalidType is returned when the type in the header of a token is not one
+// of the expected types.
+var ErrInvalidType = errors.New("the token type is invalid")
+
+// ErrTokenTooLarge is returned when the provided token is longer than the
+// maximum size allowed when parsing.
+var ErrTokenTooLarge = errors.New("the provided token is too large")
+
+// DefaultMaxTokenSize is the maximum size, in bytes, of a token accepted by
+// Parse.
+const DefaultMaxTokenSize = 1 << 20
+
 // NewToken creates a new, empty, unsigned JWT.
 func NewToken() *Token {
 	return &Token{
@@ -32,18 +73,24 @@ func NewToken() *Token {
 	}
 }
 
-// AddScope adds a scope to the token.  This operation is a no-op if the token
-// is signed.
+// AddScope adds a scope to the token.  If the scopes are encoded as a
+// space-delimited string, empty scopes and scopes containing whitespace are
+// ignored, as they could not be decoded again.  This operation is a no-op if the
+// token is signed.
 func (t *Token) AddScope(scope string) {
 	if t.IsSigned() {
 		return
 	}
 
 	scope = strings.TrimSpace(scope)
-	scopes, _ := t.Body["scope"].([]string)
+	if t.usesStringScopes() && len(ScopesFromString(scope)) != 1 {
+		return
+	}
+
+	scopes, _ := t.getScopes()
 
 	scopes = append(scopes, scope)
-	t.Body["scope"] = scopes
+	t.setScopes(scopes)
 }
 
 // RemoveScope removes a scope from the token.  This operation is a no-op if the
@@ -55,7 +102,7 @@ func (t *Token) RemoveScope(scope string) {
 
 	scope = strings.TrimSpace(scope)
 
-	scopes, ok := t.Body["scope"].([]string)
+	scopes, ok := t.getScopes()
 	if !ok {
 		return
 	}
@@ -63,7 +110,7 @@ func (t *Token) RemoveScope(scope string) {
 	for i, v := range scopes {
 		if v == scope {
 			scopes[i], scopes[len(scopes)-1] = scopes[len(scopes)-1], scopes[i]
-			t.Body["scope"] = scopes[:len(scopes)-1]
+			t.setScopes(scopes[:len(scopes)-1])
 			break
 		}
 	}
@@ -71,7 +118,7 @@ func (t *Token) RemoveScope(scope string) {
 
 // HasScope returns true if the token has the provided scope.
 func (t *Token) HasScope(scope string) bool {
-	scopes, ok := t.Body["scope"].([]string)
+	scopes, ok := t.getScopes()
 	if !ok {
 		return false
 	}
@@ -85,12 +132,103 @@ func (t *Token) HasScope(scope string) bool {
 	return false
 }
 
+// GetScopes returns a copy of the scopes of the token, regardless of how they
+// are encoded.  An empty slice is returned if the token has no scopes.
+func (t *Token) GetScopes() []string {
+	scopes, ok := t.getScopes()
+	if !ok {
+		return []string{}
+	}
+
+	return scopes
+}
+
+// HasAllScopes returns true if the token has every one of the provided scopes.
+// It returns true if no scopes are provided.
+func (t *Token) HasAllScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !t.HasScope(scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasAnyScope returns true if the token has at least one of the provided scopes.
+// It returns false if no scopes are provided.
+func (t *Token) HasAnyScope(scopes ...string) bool {
+	for _, scope := range scopes {
+		if t.HasScope(scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasScopeMatching returns true if the token has a scope matching the provided
+// colon-delimited pattern.  A * segment matches any single non-empty segment,
+// and a trailing ** segment matches one or more segments.  Other segments, and
+// ** anywhere but the end, must match exactly, so a pattern with no wildcards
+// behaves like HasScope.
+func (t *Token) HasScopeMatching(pattern string) bool {
+	scopes, ok := t.getScopes()
+	if !ok {
+		return false
+	}
+
+	for _, v := range scopes {
+		if ScopeMatches(pattern, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetStringScopes sets whether the scopes of the token are encoded as a single
+// space-delimited string, as is conventional for OAuth2 access tokens, rather
+// than as an array.  Any existing scopes are re-encoded.  This operation is a
+// no-op if the token is signed.
+func (t *Token) SetStringScopes(enabled bool) {
+	if t.IsSigned() {
+		return
+	}
+
+	t.stringScopes = enabled
+
+	scopes, ok := t.getScopes()
+	if ok {
+		delete(t.Body, "scope")
+		t.setScopes(scopes)
+	}
+}
+
+// SetSortedScopes sets whether the scopes of the token are kept sorted as they
+// are added and removed, so that the serialized token does not depend on the
+// order in which scopes were added.  Any existing scopes are sorted.  This
+// operation is a no-op if the token is signed.
+func (t *Token) SetSortedScopes(enabled bool) {
+	if t.IsSigned() {
+		return
+	}
+
+	t.sortedScopes = enabled
+
+	scopes, ok := t.getScopes()
+	if ok {
+		t.setScopes(scopes)
+	}
+}
+
 // AddClaim adds a claim to the token.
 func (t *Token) AddClaim(name string, value interface{}) {
 	if name == "scope" {
 		return
 	}
 
+	t.initBody()
 	t.Body[name] = value
 }
 
@@ -100,6 +238,8 @@ func (t *Token) RemoveClaim(name string) {
 		return
 	}
 
+	t.ensureBody()
+
 	delete(t.Body, name)
 }
 
@@ -109,6 +249,8 @@ func (t *Token) GetClaim(name string) (interface{}, bool) {
 		return nil, false
 	}
 
+	t.ensureBody()
+
 	value, ok := t.Body[name]
 	return value, ok
 }
@@ -124,6 +266,231 @@ func (t *Token) GetStringClaim(name string) (string, bool) {
 	return str, ok
 }
 
+// UnmarshalClaims decodes the body of the token into v, which must be a pointer,
+// using the standard JSON struct tags.
+func (t *Token) UnmarshalClaims(v interface{}) error {
+	t.ensureBody()
+
+	rawBody, err := json.Marshal(t.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawBody, v)
+}
+
+// SetAudience sets the audience of the token.  A single audience is stored as a
+// string, and multiple audiences are stored as an array.  This operation is a
+// no-op if the token is signed.
+func (t *Token) SetAudience(audience ...string) {
+	if t.IsSigned() {
+		return
+	}
+
+	t.initBody()
+
+	switch len(audience) {
+	case 0:
+		delete(t.Body, "aud")
+	case 1:
+		t.Body["aud"] = audience[0]
+	default:
+		t.Body["aud"] = append([]string{}, audience...)
+	}
+}
+
+// GetAudience gets the audience of the token, if present, regardless of whether
+// it is encoded as a single string or as an array.
+func (t *Token) GetAudience() ([]string, bool) {
+	t.ensureBody()
+
+	switch value := t.Body["aud"].(type) {
+	case string:
+		return []string{value}, true
+	case []string:
+		return append([]string{}, value...), true
+	case []interface{}:
+		audience := make([]string, 0, len(value))
+		for _, v := range value {
+			str, ok := v.(string)
+			if !ok {
+				return nil, false
+			}
+
+			audience = append(audience, str)
+		}
+
+		return audience, true
+	}
+
+	return nil, false
+}
+
+// HasAudience returns true if the token has the provided audience.
+func (t *Token) HasAudience(audience string) bool {
+	values, ok := t.GetAudience()
+	if !ok {
+		return false
+	}
+
+	for _, v := range values {
+		if v == audience {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetID sets the unique identifier of the token.  This operation is a no-op if
+// the token is signed.
+func (t *Token) SetID(id string) {
+	if t.IsSigned() {
+		return
+	}
+
+	t.initBody()
+	t.Body["jti"] = id
+}
+
+// GetID gets the unique identifier of the token, if present.
+func (t *Token) GetID() (string, bool) {
+	return t.GetStringClaim("jti")
+}
+
+// GenerateID sets the unique identifier of the token to a random, UUID-like
+// value.  ErrImmutable is returned if the token is signed.
+func (t *Token) GenerateID() error {
+	if t.IsSigned() {
+		return ErrImmutable
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+
+	t.SetID(id)
+	return nil
+}
+
+// GetVersion gets the version of the token format, if present.
+func (t *Token) GetVersion() (string, bool) {
+	return t.GetStringClaim("ver")
+}
+
+// GetNotBefore gets the time before which the token must not be accepted, if
+// present.
+func (t *Token) GetNotBefore() (time.Time, bool) {
+	return t.getTimeClaim("nbf")
+}
+
+// NeedsRefresh returns true if the token expires less than threshold after now,
+// including if it has already expired.  A token without an expiry time never
+// needs refreshing.
+func (t *Token) NeedsRefresh(now time.Time, threshold time.Duration) bool {
+	exp, ok := t.GetExpiry()
+	if !ok {
+		return false
+	}
+
+	return exp.Sub(now) < threshold
+}
+
+// GetConfirmationThumbprint gets the JWK thumbprint from the confirmation claim
+// of the token, if present, as used by proof-of-possession tokens.
+func (t *Token) GetConfirmationThumbprint() (string, bool) {
+	t.ensureBody()
+
+	switch cnf := t.Body["cnf"].(type) {
+	case map[string]interface{}:
+		jkt, ok := cnf["jkt"].(string)
+		return jkt, ok
+	case map[string]string:
+		jkt, ok := cnf["jkt"]
+		return jkt, ok
+	}
+
+	return "", false
+}
+
+// SetExpiry sets the expiry time of the token.  This operation is a no-op if the
+// token is signed.
+func (t *Token) SetExpiry(exp time.Time) {
+	if t.IsSigned() {
+		return
+	}
+
+	t.initBody()
+	t.Body["exp"] = exp.Unix()
+}
+
+// GetExpiry gets the expiry time of the token, if present.
+func (t *Token) GetExpiry() (time.Time, bool) {
+	return t.getTimeClaim("exp")
+}
+
+// IsExpired returns true if the token has an expiry time that is not after the
+// provided time.  A token without an expiry time never expires, but one with an
+// exp claim that is not a NumericDate is always expired.
+func (t *Token) IsExpired(now time.Time) bool {
+	exp, ok := t.GetExpiry()
+	if !ok {
+		return t.checkTimeClaim("exp") != nil
+	}
+
+	return !now.Before(exp)
+}
+
+// SetType sets the type in the header of the token, such as at+jwt for OAuth2
+// access tokens.  This operation is a no-op if the token is signed.
+func (t *Token) SetType(typ string) {
+	if t.IsSigned() {
+		return
+	}
+
+	t.Header.Type = typ
+}
+
+// SetContentType sets the cty header of the token, such as JWT for nested
+// tokens.  The header is held in Header.Extra, so it is covered by the
+// signature.  An empty content type removes the header.  This operation is a
+// no-op if the token is signed.
+func (t *Token) SetContentType(cty string) {
+	if t.IsSigned() {
+		return
+	}
+
+	if cty == "" {
+		delete(t.Header.Extra, "cty")
+		return
+	}
+
+	if t.Header.Extra == nil {
+		t.Header.Extra = map[string]interface{}{}
+	}
+
+	t.Header.Extra["cty"] = cty
+}
+
+// GetContentType gets the cty header of the token, if present.
+func (t *Token) GetContentType() (string, bool) {
+	cty, ok := t.Header.Extra["cty"].(string)
+	return cty, ok
+}
+
+// HasKeyID returns true if the header of the token identifies the key it was
+// signed with as kid.
+func (t *Token) HasKeyID(kid string) bool {
+	return t.Header.KeyID != "" && t.Header.KeyID == kid
+}
+
+// AlgorithmStrength returns the strength of the algorithm in the header of the
+// token.
+func (t *Token) AlgorithmStrength() int {
+	return t.Header.Algorithm.Strength()
+}
+
 // IsSigned returns true when the token has a signature present.  This method
 // does not state anything about the validity of an attached signature.
 func (t *Token) IsSigned() bool {
@@ -132,13 +499,66 @@ func (t *Token) IsSigned() bool {
 
 // Sign signs the token with the provided Signer.
 func (t *Token) Sign(signer Signer) error {
+	return t.SignContext(context.Background(), signer)
+}
+
+// SignWith signs the token with the provided Signer, first setting the iat claim
+// to the current time, unless the claim is already present.  The provided
+// options can also set the nbf claim and control the current time.  The claims
+// are set before the token is serialized, so they are covered by the signature.
+func (t *Token) SignWith(signer Signer, opts ...SignOption) error {
 	if t.IsSigned() {
 		return ErrImmutable
 	}
 
-	newHeader := Header{
-		Type:      t.Header.Type,
-		Algorithm: signer.Algorithm(),
+	t.initBody()
+
+	config := newSignConfig(opts)
+	now := config.clock().Unix()
+
+	var added []string
+	if _, ok := t.Body["iat"]; !ok {
+		t.Body["iat"] = now
+		added = append(added, "iat")
+	}
+
+	if _, ok := t.Body["nbf"]; config.notBefore && !ok {
+		t.Body["nbf"] = now
+		added = append(added, "nbf")
+	}
+
+	err := t.Sign(signer)
+	if err != nil {
+		for _, name := range added {
+			delete(t.Body, name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// SignContext signs the token with the provided Signer.  If the Signer is a
+// ContextSigner, ctx is passed to it so that remote signing operations can be
+// cancelled; otherwise ctx is ignored.  If the Signer is a KeyIDSigner, the kid
+// header is set to the identifier of its key.  The scope claim is normalized to
+// the representation AddScope would produce before the token is serialized.
+func (t *Token) SignContext(ctx context.Context, signer Signer) error {
+	if t.IsSigned() {
+		return ErrImmutable
+	}
+
+	scopes, ok := t.getScopes()
+	if ok {
+		t.setScopes(scopes)
+	}
+
+	newHeader := t.Header
+	newHeader.Algorithm = signer.Algorithm()
+
+	if keyIDSigner, ok := signer.(KeyIDSigner); ok {
+		newHeader.KeyID = keyIDSigner.KeyID()
 	}
 
 	b64HeaderAndBody, err := serializeHeaderAndBody(newHeader, t.Body)
@@ -146,7 +566,13 @@ func (t *Token) Sign(signer Signer) error {
 		return err
 	}
 
-	signature, err := signer.Sign(b64HeaderAndBody)
+	var signature []byte
+	if contextSigner, ok := signer.(ContextSigner); ok {
+		signature, err = contextSigner.SignContext(ctx, b64HeaderAndBody)
+	} else {
+		signature, err = signer.Sign(b64HeaderAndBody)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -156,87 +582,503 @@ func (t *Token) Sign(signer Signer) error {
 	return nil
 }
 
+// keyBoundHeaderParams are the header parameters that identify or describe the
+// key a token was signed with, and so no longer apply once it is re-signed.
+var keyBoundHeaderParams = []string{"jku", "jwk", "x5u", "x5c", "x5t", "x5t#S256"}
+
+// Resign creates a new token with the same header and claims as this token,
+// signed with the provided Signer.  The Signer may use a different algorithm to
+// the one this token was signed with.  The kid header and any other header
+// parameters bound to the original key are removed before signing.  This token
+// is left unchanged.
+func (t *Token) Resign(signer Signer) (*Token, error) {
+	token := t.Unsign()
+	token.Header.KeyID = ""
+
+	for _, name := range keyBoundHeaderParams {
+		delete(token.Header.Extra, name)
+	}
+
+	err := token.Sign(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Clone creates a deep copy of the token that shares no state with it.
+func (t *Token) Clone() *Token {
+	t.ensureBody()
+
+	token := &Token{
+		Header:           t.Header,
+		Body:             cloneValue(t.Body).(Body),
+		rawHeaderAndBody: t.rawHeaderAndBody,
+		stringScopes:     t.stringScopes,
+		sortedScopes:     t.sortedScopes,
+	}
+
+	if t.Header.Extra != nil {
+		token.Header.Extra = cloneValue(t.Header.Extra).(map[string]interface{})
+	}
+
+	if t.Signature != nil {
+		token.Signature = append([]byte{}, t.Signature...)
+	}
+
+	return token
+}
+
+// Unsign creates a deep copy of the token without its signature, so that its
+// claims can be changed and it can be signed again.  This token is left
+// unchanged.
+func (t *Token) Unsign() *Token {
+	token := t.Clone()
+	token.Signature = nil
+	token.rawHeaderAndBody = ""
+
+	return token
+}
+
 // Verify verifies the signature on the token, if present, using the provided
-// verifier.
+// verifier.  Tokens produced by Parse are verified against the header and body
+// exactly as they were received, rather than as they would be re-serialized,
+// unless the header or body has since been modified.
 func (t *Token) Verify(verifier Verifier) bool {
 	if !t.IsSigned() {
 		return false
 	}
 
+	t.ensureBody()
+
 	b64HeaderAndBody, err := serializeHeaderAndBody(t.Header, t.Body)
 	if err != nil {
 		return false
 	}
 
+	if t.rawHeaderAndBody != "" && t.rawHeaderAndBody != b64HeaderAndBody {
+		// The token was parsed from a producer that serializes differently, so
+		// the original bytes are verified provided the header and body have not
+		// been modified since.
+		original, err := canonicalizeHeaderAndBody(t.rawHeaderAndBody)
+		if err == nil && original == b64HeaderAndBody {
+			b64HeaderAndBody = t.rawHeaderAndBody
+		}
+	}
+
 	return verifier.Verify(b64HeaderAndBody, t.Signature)
 }
 
+// VerifyAny verifies the signature on the token, if present, using each of the
+// provided verifiers in turn, returning true as soon as one accepts it.  It
+// returns false if no verifiers are provided.
+func (t *Token) VerifyAny(verifiers ...Verifier) bool {
+	for _, verifier := range verifiers {
+		if t.Verify(verifier) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the token against the requirements described by the provided
+// options, returning the first error encountered.  The signature on the token
+// is only verified if WithVerifier is provided.
+func (t *Token) Validate(opts ...ValidateOption) error {
+	return NewValidator(opts...).Validate(t)
+}
+
 // Serialize serializes the token to its string form.
 func (t *Token) Serialize() (string, error) {
-	b64HeaderAndBody, err := serializeHeaderAndBody(t.Header, t.Body)
+	sb := &strings.Builder{}
+
+	_, err := t.WriteTo(sb)
 	if err != nil {
 		return "", err
 	}
 
-	b64Signature := base64.RawURLEncoding.EncodeToString(t.Signature)
+	return sb.String(), nil
+}
+
+// WriteTo writes the token in its string form to w, returning the number of
+// bytes written.
+func (t *Token) WriteTo(w io.Writer) (int64, error) {
+	t.ensureBody()
+
+	b64HeaderAndBody, err := serializeHeaderAndBody(t.Header, t.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	b64Signature := make([]byte, base64.RawURLEncoding.EncodedLen(len(t.Signature))+1)
+	b64Signature[0] = '.'
+	base64.RawURLEncoding.Encode(b64Signature[1:], t.Signature)
 
-	return fmt.Sprintf("%v.%v", b64HeaderAndBody, b64Signature), nil
+	n, err := io.WriteString(w, b64HeaderAndBody)
+	if err != nil {
+		return int64(n), err
+	}
+
+	m, err := w.Write(b64Signature)
+	return int64(n + m), err
+}
+
+// Parse parses the provided string token with the provided options.  Tokens
+// longer than DefaultMaxTokenSize are rejected with ErrTokenTooLarge.  To
+// accept tokens from non-conformant producers, segments that are not valid
+// unpadded base64url are also decoded as padded base64url or as standard
+// base64; use ParseStrict to reject them instead.
+func Parse(tokenString string, opts ...ParseOption) (*Token, error) {
+	return ParseWithLimit(tokenString, DefaultMaxTokenSize, opts...)
+}
+
+// ParseStrict parses the provided string token in the same way as Parse, except
+// that every segment must be unpadded base64url, as required by RFC 7515, and
+// the header is validated: alg must be present and recognized, typ must be a
+// string when present, and crit must not list parameters that are not
+// understood.
+func ParseStrict(tokenString string, opts ...ParseOption) (*Token, error) {
+	return Parse(tokenString, append(append([]ParseOption{}, opts...), strictParse)...)
 }
 
-// Parse parses the provided string token.
-func Parse(tokenString string) (*Token, error) {
+// ParseWithLimit parses the provided string token with the provided options,
+// rejecting it with ErrTokenTooLarge before it is decoded if it is longer than
+// maxBytes.  Tokens without exactly three segments are rejected with
+// ErrWrongSegmentCount, and tokens with an empty header or body segment, or an
+// empty signature segment and an algorithm other than none, are rejected with
+// ErrEmptySegment.
+func ParseWithLimit(tokenString string, maxBytes int, opts ...ParseOption) (*Token, error) {
+	config := newParseConfig(opts)
+
+	if len(tokenString) > maxBytes {
+		return nil, ErrTokenTooLarge
+	}
+
 	spl := strings.Split(tokenString, ".")
 	if len(spl) != 3 {
-		return nil, ErrInvalidTokenStructure
+		return nil, fmt.Errorf("%w: expected 3 but found %v", ErrWrongSegmentCount, len(spl))
+	}
+
+	if spl[0] == "" {
+		return nil, fmt.Errorf("%w: header", ErrEmptySegment)
 	}
 
-	rawHeader, err := base64.RawURLEncoding.DecodeString(spl[0])
+	if spl[1] == "" {
+		return nil, fmt.Errorf("%w: body", ErrEmptySegment)
+	}
+
+	rawHeader, err := config.decodeSegment(spl[0])
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to decode header segment: %w", err)
 	}
 
-	rawBody, err := base64.RawURLEncoding.DecodeString(spl[1])
+	rawSignature, err := config.decodeSegment(spl[2])
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to decode signature segment: %w", err)
+	}
+
+	if hasDuplicateHeaderParams(rawHeader) {
+		return nil, ErrDuplicateHeaderParam
 	}
 
-	rawSignature, err := base64.RawURLEncoding.DecodeString(spl[2])
+	err = config.checkHeader(rawHeader)
 	if err != nil {
 		return nil, err
 	}
 
 	header := Header{}
 	err = json.Unmarshal(rawHeader, &header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal header: %w", err)
+	}
+
+	if spl[2] == "" && !strings.EqualFold(string(header.Algorithm), string(None)) {
+		return nil, fmt.Errorf("%w: signature", ErrEmptySegment)
+	}
+
+	token := &Token{
+		Header:           header,
+		Signature:        rawSignature,
+		rawHeaderAndBody: fmt.Sprintf("%v.%v", spl[0], spl[1]),
+	}
+
+	if config.lazy {
+		token.lazy = &lazyBody{segment: spl[1], config: config}
+		return token, nil
+	}
+
+	token.Body, err = decodeBody(spl[1], config)
 	if err != nil {
 		return nil, err
 	}
 
+	return token, nil
+}
+
+func decodeBody(segment string, config *parseConfig) (Body, error) {
+	rawBody, err := config.decodeSegment(segment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode body segment: %w", err)
+	}
+
+	if hasDuplicateKeys(rawBody) {
+		return nil, ErrDuplicateClaim
+	}
+
+	if json.Valid(rawBody) && !isJSONObject(rawBody) {
+		return nil, ErrInvalidTokenBody
+	}
+
 	body := Body{}
 	err = json.Unmarshal(rawBody, &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal body: %w", err)
+	}
+
+	err = unmarshalClaims(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal body: %w", err)
+	}
+
+	err = config.checkLimits(&Token{Body: body})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Token{
-		Header:    header,
-		Body:      body,
-		Signature: rawSignature,
-	}, nil
+	return body, nil
+}
+
+func (t *Token) getScopes() ([]string, bool) {
+	t.ensureBody()
+
+	switch value := t.Body["scope"].(type) {
+	case string:
+		return ScopesFromString(value), true
+	case []string:
+		return append([]string{}, value...), true
+	case []interface{}:
+		scopes := make([]string, 0, len(value))
+		for _, v := range value {
+			str, ok := v.(string)
+			if !ok {
+				return nil, false
+			}
+
+			scopes = append(scopes, str)
+		}
+
+		return scopes, true
+	}
+
+	return nil, false
+}
+
+func (t *Token) setScopes(scopes []string) {
+	t.initBody()
+
+	if t.sortedScopes {
+		sort.Strings(scopes)
+	}
+
+	if t.usesStringScopes() {
+		t.Body["scope"] = ScopesToString(scopes)
+		return
+	}
+
+	t.Body["scope"] = scopes
+}
+
+func (t *Token) usesStringScopes() bool {
+	t.ensureBody()
+
+	_, isString := t.Body["scope"].(string)
+	return t.stringScopes || isString
+}
+
+// initBody initializes the body of a token that was not created by NewToken,
+// such as the zero value, so that claims can be set on it.
+func (t *Token) initBody() {
+	if t.Body == nil {
+		t.Body = Body{}
+	}
+}
+
+func (t *Token) getTimeClaim(name string) (time.Time, bool) {
+	t.ensureBody()
+
+	switch value := t.Body[name].(type) {
+	case float64:
+		return time.Unix(int64(value), 0), true
+	case int64:
+		return time.Unix(value, 0), true
+	case int:
+		return time.Unix(int64(value), 0), true
+	case json.Number:
+		seconds, err := value.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return time.Unix(seconds, 0), true
+	}
+
+	return time.Time{}, false
+}
+
+// checkTimeClaim returns ErrInvalidTimeClaim if the named claim is present but
+// is not a NumericDate.  Absent claims are valid.
+func (t *Token) checkTimeClaim(name string) error {
+	t.ensureBody()
+
+	if _, ok := t.Body[name]; !ok {
+		return nil
+	}
+
+	if _, ok := t.getTimeClaim(name); !ok {
+		return fmt.Errorf("%w: %v", ErrInvalidTimeClaim, name)
+	}
+
+	return nil
+}
+
+func cloneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Body:
+		if v == nil {
+			return Body{}
+		}
+
+		return Body(cloneValue(map[string]interface{}(v)).(map[string]interface{}))
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			clone[k] = cloneValue(e)
+		}
+
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, e := range v {
+			clone[i] = cloneValue(e)
+		}
+
+		return clone
+	case []string:
+		return append([]string{}, v...)
+	}
+
+	return value
+}
+
+func isJSONObject(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+func generateID() (string, error) {
+	raw := make([]byte, 16)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+
+	raw[6] = (raw[6] & 0x0f) | 0x40
+	raw[8] = (raw[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", raw[0:4], raw[4:6], raw[6:8], raw[8:10], raw[10:16]), nil
+}
+
+// serializeBuffers holds the scratch space used to serialize a header and body,
+// and is pooled as serialization happens on every sign and verify.
+type serializeBuffers struct {
+	json bytes.Buffer
+	b64  []byte
+	out  bytes.Buffer
+}
+
+var serializeBuffersPool = sync.Pool{
+	New: func() interface{} {
+		return &serializeBuffers{}
+	},
+}
+
+// canonicalizeHeaderAndBody decodes the provided header and body segments and
+// re-serializes them as serializeHeaderAndBody would.
+func canonicalizeHeaderAndBody(b64HeaderAndBody string) (string, error) {
+	config := &parseConfig{}
+	spl := strings.Split(b64HeaderAndBody, ".")
+
+	rawHeader, err := config.decodeSegment(spl[0])
+	if err != nil {
+		return "", err
+	}
+
+	header := Header{}
+	err = json.Unmarshal(rawHeader, &header)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := decodeBody(spl[1], config)
+	if err != nil {
+		return "", err
+	}
+
+	return serializeHeaderAndBody(header, body)
 }
 
 func serializeHeaderAndBody(header Header, body Body) (string, error) {
-	rawHeader, err := json.Marshal(header)
+	if body == nil {
+		body = Body{}
+	}
+
+	body, err := marshalClaims(body)
 	if err != nil {
 		return "", err
 	}
 
-	rawBody, err := json.Marshal(body)
+	buffers := serializeBuffersPool.Get().(*serializeBuffers)
+	defer serializeBuffersPool.Put(buffers)
+
+	buffers.out.Reset()
+
+	err = buffers.writeSegment(header)
 	if err != nil {
 		return "", err
 	}
 
-	b64Header := base64.RawURLEncoding.EncodeToString(rawHeader)
-	b64Body := base64.RawURLEncoding.EncodeToString(rawBody)
+	buffers.out.WriteByte('.')
+
+	err = buffers.writeSegment(body)
+	if err != nil {
+		return "", err
+	}
 
-	return fmt.Sprintf("%v.%v", b64Header, b64Body), nil
+	return buffers.out.String(), nil
+}
+
+func (b *serializeBuffers) writeSegment(v interface{}) error {
+	b.json.Reset()
+
+	err := json.NewEncoder(&b.json).Encode(v)
+	if err != nil {
+		return err
+	}
+
+	// Encode terminates the value with a newline that json.Marshal would not.
+	raw := b.json.Bytes()
+	raw = raw[:len(raw)-1]
+
+	n := base64.RawURLEncoding.EncodedLen(len(raw))
+	if cap(b.b64) < n {
+		b.b64 = make([]byte, n)
+	}
+
+	b64 := b.b64[:n]
+	base64.RawURLEncoding.Encode(b64, raw)
+	b.out.Write(b64)
+
+	return nil
 }
@@ -0,0 +1,29 @@
+package jwt
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrMissingAccessToken is returned when an OAuth2 token response does not
+// contain an access token.
+var ErrMissingAccessToken = errors.New("the token response does not contain an access token")
+
+// FromTokenResponse parses the access token contained in the provided OAuth2
+// token response, such as {"access_token":"...","token_type":"Bearer"}.
+func FromTokenResponse(response []byte) (*Token, error) {
+	tokenResponse := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+
+	err := json.Unmarshal(response, &tokenResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return nil, ErrMissingAccessToken
+	}
+
+	return Parse(tokenResponse.AccessToken)
+}
@@ -0,0 +1,41 @@
+package jwt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestFromTokenResponse(t *testing.T) {
+	// Arrange.
+	token1 := NewToken()
+	token1.AddClaim("iss", "Test Issuer")
+
+	tokenString, err := token1.Serialize()
+	test.That(t, err).IsNil()
+
+	response := fmt.Sprintf(`{"access_token":"%v","token_type":"Bearer","expires_in":3600}`, tokenString)
+
+	// Act.
+	token2, err := FromTokenResponse([]byte(response))
+
+	// Assert.
+	test.That(t, err).IsNil()
+
+	iss, ok := token2.GetStringClaim("iss")
+	test.That(t, ok).IsTrue()
+	test.That(t, iss).IsEqualTo("Test Issuer")
+}
+
+func TestFromTokenResponseMissingAccessToken(t *testing.T) {
+	// Arrange.
+	response := `{"token_type":"Bearer","expires_in":3600}`
+
+	// Act.
+	token, err := FromTokenResponse([]byte(response))
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrMissingAccessToken)
+	test.That(t, token).IsNil()
+}
@@ -0,0 +1,300 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrExpired is returned when a token has expired.
+var ErrExpired = errors.New("the token has expired")
+
+// ErrNotYetValid is returned when a token is used before its not-before time.
+var ErrNotYetValid = errors.New("the token is not valid yet")
+
+// ErrInvalidTimeClaim is returned when the exp or nbf claim of a token is present
+// but is not a NumericDate.
+var ErrInvalidTimeClaim = errors.New("the token has an invalid time claim")
+
+// ErrInsufficientScope is returned when a token does not have a required scope.
+var ErrInsufficientScope = errors.New("the token does not have a required scope")
+
+// ErrInvalidIssuer is returned when the issuer of a token is not the expected
+// issuer.
+var ErrInvalidIssuer = errors.New("the token issuer is invalid")
+
+// ErrInvalidAudience is returned when the audience of a token does not contain
+// the expected audience.
+var ErrInvalidAudience = errors.New("the token audience is invalid")
+
+// ErrInvalidAuthorizedParty is returned when the authorized party of a token is
+// missing or is not the expected client.
+var ErrInvalidAuthorizedParty = errors.New("the token authorized party is invalid")
+
+// ErrMissingClaim is returned when a token does not have a required claim.
+var ErrMissingClaim = errors.New("the token is missing a required claim")
+
+// ErrConfirmationMismatch is returned when the confirmation claim of a token
+// does not contain the expected key thumbprint.
+var ErrConfirmationMismatch = errors.New("the token confirmation does not match")
+
+// ErrUnsupportedTokenVersion is returned when the version of a token is not the
+// expected version.
+var ErrUnsupportedTokenVersion = errors.New("the token version is not supported")
+
+// Validator checks that tokens satisfy a configured set of requirements.  The
+// signature is checked first, if a verifier is configured, followed by the exp
+// and nbf claims and then the remaining requirements in the order their options
+// were provided.
+type Validator struct {
+	verifier Verifier
+	leeway   time.Duration
+	clock    func() time.Time
+	checks   []func(t *Token) error
+}
+
+// ValidateOption configures the requirements checked by a Validator.
+type ValidateOption func(v *Validator)
+
+// NewValidator creates a new Validator with the provided options.
+func NewValidator(opts ...ValidateOption) *Validator {
+	v := &Validator{}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Validate checks the token against each configured requirement in turn,
+// returning the first error encountered.  The body of a token parsed with
+// WithLazyBody is decoded first, and any error decoding it is returned.
+func (v *Validator) Validate(t *Token) error {
+	err := t.DecodeBody()
+	if err != nil {
+		return err
+	}
+
+	for _, check := range v.orderedChecks() {
+		err := check(t)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateAll checks the token against every configured requirement, returning
+// all of the errors encountered.  It returns nil if the token satisfies every
+// requirement.  If the body of a token parsed with WithLazyBody cannot be
+// decoded, only that error is returned.
+func (v *Validator) ValidateAll(t *Token) []error {
+	err := t.DecodeBody()
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, check := range v.orderedChecks() {
+		err := check(t)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// WithVerifier requires that the signature on the token is verified by the
+// provided verifier.
+func WithVerifier(verifier Verifier) ValidateOption {
+	return func(v *Validator) {
+		v.verifier = verifier
+	}
+}
+
+// WithLeeway allows for clock skew when checking the exp and nbf claims of the
+// token.
+func WithLeeway(leeway time.Duration) ValidateOption {
+	return func(v *Validator) {
+		v.leeway = leeway
+	}
+}
+
+// WithClock sets the clock used to check the exp and nbf claims of the token,
+// which defaults to time.Now.
+func WithClock(clock func() time.Time) ValidateOption {
+	return func(v *Validator) {
+		v.clock = clock
+	}
+}
+
+// WithRequiredClaims requires that the token has each of the named claims,
+// returning ErrMissingClaim wrapped with the name of the first that is missing.
+// A claim with a null value is treated as missing.  Unlike GetClaim, scope can be
+// required.
+func WithRequiredClaims(names ...string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			t.ensureBody()
+
+			for _, name := range names {
+				if t.Body[name] == nil {
+					return fmt.Errorf("%w: %v", ErrMissingClaim, name)
+				}
+			}
+
+			return nil
+		})
+	}
+}
+
+// WithExpectedIssuer requires that the issuer of the token is expected.
+func WithExpectedIssuer(expected string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			iss, ok := t.GetStringClaim("iss")
+			if !ok || iss != expected {
+				return ErrInvalidIssuer
+			}
+
+			return nil
+		})
+	}
+}
+
+// WithExpectedAudience requires that the audience of the token contains
+// expected.
+func WithExpectedAudience(expected string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			if !t.HasAudience(expected) {
+				return ErrInvalidAudience
+			}
+
+			return nil
+		})
+	}
+}
+
+// WithExpectedType requires that the type in the header of the token is
+// expected, compared case-insensitively.
+func WithExpectedType(expected string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			if !strings.EqualFold(t.Header.Type, expected) {
+				return ErrInvalidType
+			}
+
+			return nil
+		})
+	}
+}
+
+// RequireExactAudience requires that the audience of the token is encoded as a
+// single string equal to expected.  An array of audiences is rejected even if it
+// contains expected.
+func RequireExactAudience(expected string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			t.ensureBody()
+
+			aud, ok := t.Body["aud"].(string)
+			if !ok || aud != expected {
+				return ErrInvalidAudience
+			}
+
+			return nil
+		})
+	}
+}
+
+// RequireConsistentAudienceAndAzp implements the OpenID Connect rule that a
+// token with multiple audiences must have an azp claim identifying the client it
+// was issued to.  If the token has more than one audience, azp must be present
+// and equal clientID.
+func RequireConsistentAudienceAndAzp(clientID string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			aud, _ := t.GetAudience()
+			if len(aud) <= 1 {
+				return nil
+			}
+
+			azp, ok := t.GetStringClaim("azp")
+			if !ok || azp != clientID {
+				return ErrInvalidAuthorizedParty
+			}
+
+			return nil
+		})
+	}
+}
+
+// RequireVersion requires that the version of the token is expected.
+func RequireVersion(expected string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			version, ok := t.GetVersion()
+			if !ok || version != expected {
+				return ErrUnsupportedTokenVersion
+			}
+
+			return nil
+		})
+	}
+}
+
+// RequireConfirmation requires that the confirmation claim of the token contains
+// the provided JWK thumbprint, binding the token to the key it identifies.
+func RequireConfirmation(thumbprint string) ValidateOption {
+	return func(v *Validator) {
+		v.checks = append(v.checks, func(t *Token) error {
+			jkt, ok := t.GetConfirmationThumbprint()
+			if !ok || jkt != thumbprint {
+				return ErrConfirmationMismatch
+			}
+
+			return nil
+		})
+	}
+}
+
+func (v *Validator) orderedChecks() []func(t *Token) error {
+	return append([]func(t *Token) error{v.checkSignature, v.checkTimes}, v.checks...)
+}
+
+func (v *Validator) checkSignature(t *Token) error {
+	if v.verifier != nil && !t.Verify(v.verifier) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (v *Validator) checkTimes(t *Token) error {
+	now := time.Now()
+	if v.clock != nil {
+		now = v.clock()
+	}
+
+	for _, name := range []string{"exp", "nbf"} {
+		err := t.checkTimeClaim(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if t.IsExpired(now.Add(-v.leeway)) {
+		return ErrExpired
+	}
+
+	nbf, ok := t.GetNotBefore()
+	if ok && now.Add(v.leeway).Before(nbf) {
+		return ErrNotYetValid
+	}
+
+	return nil
+}
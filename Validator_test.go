@@ -0,0 +1,347 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ljpx/test"
+)
+
+func TestValidatorNoOptions(t *testing.T) {
+	// Arrange.
+	validator := NewValidator()
+
+	// Act.
+	err := validator.Validate(NewToken())
+
+	// Assert.
+	test.That(t, err).IsNil()
+}
+
+func TestValidateRequireVersion(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddClaim("ver", "2")
+
+	// Act.
+	matchErr := token.Validate(RequireVersion("2"))
+	mismatchErr := token.Validate(RequireVersion("1"))
+	missingErr := NewToken().Validate(RequireVersion("2"))
+
+	// Assert.
+	test.That(t, matchErr).IsNil()
+	test.That(t, mismatchErr).IsEqualTo(ErrUnsupportedTokenVersion)
+	test.That(t, missingErr).IsEqualTo(ErrUnsupportedTokenVersion)
+}
+
+func TestValidateExpectedIssuer(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddClaim("iss", "Test Issuer")
+
+	// Act.
+	matchErr := token.Validate(WithExpectedIssuer("Test Issuer"))
+	mismatchErr := token.Validate(WithExpectedIssuer("Other Issuer"))
+	missingErr := NewToken().Validate(WithExpectedIssuer("Test Issuer"))
+
+	// Assert.
+	test.That(t, matchErr).IsNil()
+	test.That(t, mismatchErr).IsEqualTo(ErrInvalidIssuer)
+	test.That(t, missingErr).IsEqualTo(ErrInvalidIssuer)
+}
+
+func TestValidateExpectedAudience(t *testing.T) {
+	// Arrange.
+	singleToken := parsedToken(t, func(token *Token) { token.SetAudience("api") })
+	arrayToken := parsedToken(t, func(token *Token) { token.SetAudience("web", "api") })
+	absentToken := parsedToken(t, func(token *Token) {})
+
+	// Act.
+	singleErr := singleToken.Validate(WithExpectedAudience("api"))
+	arrayErr := arrayToken.Validate(WithExpectedAudience("api"))
+	mismatchErr := arrayToken.Validate(WithExpectedAudience("cli"))
+	absentErr := absentToken.Validate(WithExpectedAudience("api"))
+
+	// Assert.
+	test.That(t, singleErr).IsNil()
+	test.That(t, arrayErr).IsNil()
+	test.That(t, mismatchErr).IsEqualTo(ErrInvalidAudience)
+	test.That(t, absentErr).IsEqualTo(ErrInvalidAudience)
+}
+
+func TestValidateStopsAtFirstError(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.AddClaim("iss", "Other Issuer")
+
+	// Act.
+	err := token.Validate(
+		WithExpectedIssuer("Test Issuer"),
+		WithExpectedAudience("api"),
+	)
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrInvalidIssuer)
+}
+
+func TestValidatorFullyConfigured(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	now := time.Unix(1600000000, 0)
+
+	validator := NewValidator(
+		WithVerifier(NewES256Verifier(&privateKey.PublicKey)),
+		WithLeeway(time.Minute),
+		WithClock(func() time.Time { return now }),
+		WithExpectedIssuer("Test Issuer"),
+		WithExpectedAudience("api"),
+		WithRequiredClaims("sub"),
+	)
+
+	signed := func(signer Signer, mutate func(token *Token)) *Token {
+		token := NewToken()
+		token.AddClaim("iss", "Test Issuer")
+		token.AddClaim("sub", "user-1")
+		token.AddClaim("nbf", now.Unix())
+		token.SetAudience("api")
+		token.SetExpiry(now.Add(time.Hour))
+		mutate(token)
+
+		test.That(t, token.Sign(signer)).IsNil()
+		return token
+	}
+
+	signer := NewES256Signer(privateKey)
+
+	testCases := []struct {
+		name     string
+		token    *Token
+		expected error
+	}{
+		{"valid", signed(signer, func(token *Token) {}), nil},
+		{"wrong key", signed(NewES256Signer(otherKey), func(token *Token) {}), ErrInvalidSignature},
+		{"unsigned", NewToken(), ErrInvalidSignature},
+		{"expired", signed(signer, func(token *Token) { token.SetExpiry(now.Add(-2 * time.Minute)) }), ErrExpired},
+		{"expired within leeway", signed(signer, func(token *Token) { token.SetExpiry(now.Add(-time.Second)) }), nil},
+		{"not yet valid", signed(signer, func(token *Token) { token.AddClaim("nbf", now.Add(2*time.Minute).Unix()) }), ErrNotYetValid},
+		{"not yet valid within leeway", signed(signer, func(token *Token) { token.AddClaim("nbf", now.Add(time.Second).Unix()) }), nil},
+		{"wrong issuer", signed(signer, func(token *Token) { token.AddClaim("iss", "Other Issuer") }), ErrInvalidIssuer},
+		{"wrong audience", signed(signer, func(token *Token) { token.SetAudience("cli") }), ErrInvalidAudience},
+		{"missing claim", signed(signer, func(token *Token) { token.RemoveClaim("sub") }), ErrMissingClaim},
+		{"expired with wrong issuer", signed(signer, func(token *Token) {
+			token.SetExpiry(now.Add(-time.Hour))
+			token.AddClaim("iss", "Other Issuer")
+		}), ErrExpired},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act.
+			err := validator.Validate(testCase.token)
+
+			// Assert.
+			test.That(t, errors.Is(err, testCase.expected)).IsTrue()
+		})
+	}
+}
+
+func TestValidateWithRequiredClaims(t *testing.T) {
+	// Arrange.
+	token := parsedToken(t, func(token *Token) {
+		token.AddClaim("iss", "Test Issuer")
+		token.AddClaim("sub", nil)
+		token.AddScope("user:read")
+	})
+
+	// Act.
+	presentErr := token.Validate(WithRequiredClaims("iss", "scope"))
+	nullErr := token.Validate(WithRequiredClaims("iss", "sub", "exp"))
+	missingErr := token.Validate(WithRequiredClaims("iss", "exp"))
+	missingScopeErr := NewToken().Validate(WithRequiredClaims("scope"))
+
+	// Assert.
+	test.That(t, presentErr).IsNil()
+	test.That(t, errors.Is(nullErr, ErrMissingClaim)).IsTrue()
+	test.That(t, nullErr.Error()).IsEqualTo("the token is missing a required claim: sub")
+	test.That(t, missingErr.Error()).IsEqualTo("the token is missing a required claim: exp")
+	test.That(t, missingScopeErr.Error()).IsEqualTo("the token is missing a required claim: scope")
+}
+
+func TestValidateRejectsMalformedTimeClaims(t *testing.T) {
+	// Arrange.
+	malformedExp := parsedToken(t, func(token *Token) { token.AddClaim("exp", "1") })
+	malformedNbf := parsedToken(t, func(token *Token) { token.AddClaim("nbf", map[string]interface{}{}) })
+	nullExp := parsedToken(t, func(token *Token) { token.AddClaim("exp", nil) })
+
+	// Act.
+	expErr := malformedExp.Validate()
+	nbfErr := malformedNbf.Validate()
+	nullErr := nullExp.Validate()
+
+	// Assert.
+	test.That(t, errors.Is(expErr, ErrInvalidTimeClaim)).IsTrue()
+	test.That(t, expErr.Error()).IsEqualTo("the token has an invalid time claim: exp")
+	test.That(t, errors.Is(nbfErr, ErrInvalidTimeClaim)).IsTrue()
+	test.That(t, errors.Is(nullErr, ErrInvalidTimeClaim)).IsTrue()
+}
+
+func TestValidatorZeroValueChecksExpiry(t *testing.T) {
+	// Arrange.
+	token := NewToken()
+	token.SetExpiry(time.Now().Add(-time.Minute))
+
+	// Act.
+	err := (&Validator{}).Validate(token)
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrExpired)
+}
+
+func parsedToken(t *testing.T, build func(token *Token)) *Token {
+	token := NewToken()
+	build(token)
+
+	tokenString, err := token.Serialize()
+	test.That(t, err).IsNil()
+
+	parsed, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	return parsed
+}
+
+func TestValidateRequireExactAudience(t *testing.T) {
+	// Arrange.
+	exactToken := parsedToken(t, func(token *Token) { token.SetAudience("api") })
+	arrayToken := parsedToken(t, func(token *Token) { token.SetAudience("api", "web") })
+	mismatchToken := parsedToken(t, func(token *Token) { token.SetAudience("web") })
+
+	// Act.
+	exactErr := exactToken.Validate(RequireExactAudience("api"))
+	arrayErr := arrayToken.Validate(RequireExactAudience("api"))
+	mismatchErr := mismatchToken.Validate(RequireExactAudience("api"))
+
+	// Assert.
+	test.That(t, exactErr).IsNil()
+	test.That(t, arrayErr).IsEqualTo(ErrInvalidAudience)
+	test.That(t, mismatchErr).IsEqualTo(ErrInvalidAudience)
+}
+
+func TestValidateRequireConsistentAudienceAndAzp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		audience []string
+		azp      string
+		expected error
+	}{
+		{"no audience", nil, "", nil},
+		{"single audience", []string{"client-1"}, "", nil},
+		{"single audience with other azp", []string{"client-1"}, "client-2", nil},
+		{"multiple audiences with correct azp", []string{"client-1", "api"}, "client-1", nil},
+		{"multiple audiences with missing azp", []string{"client-1", "api"}, "", ErrInvalidAuthorizedParty},
+		{"multiple audiences with wrong azp", []string{"client-1", "api"}, "client-2", ErrInvalidAuthorizedParty},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange.
+			token := parsedToken(t, func(token *Token) {
+				token.SetAudience(testCase.audience...)
+				if testCase.azp != "" {
+					token.AddClaim("azp", testCase.azp)
+				}
+			})
+
+			// Act.
+			err := token.Validate(RequireConsistentAudienceAndAzp("client-1"))
+
+			// Assert.
+			test.That(t, err).IsEqualTo(testCase.expected)
+		})
+	}
+}
+
+func TestValidateRequireConfirmation(t *testing.T) {
+	// Arrange.
+	token := parsedToken(t, func(token *Token) {
+		token.AddClaim("cnf", map[string]interface{}{"jkt": "0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"})
+	})
+
+	// Act.
+	matchErr := token.Validate(RequireConfirmation("0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"))
+	mismatchErr := token.Validate(RequireConfirmation("NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"))
+	missingErr := NewToken().Validate(RequireConfirmation("0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"))
+
+	// Assert.
+	test.That(t, matchErr).IsNil()
+	test.That(t, mismatchErr).IsEqualTo(ErrConfirmationMismatch)
+	test.That(t, missingErr).IsEqualTo(ErrConfirmationMismatch)
+}
+
+func TestValidatorValidateAll(t *testing.T) {
+	// Arrange.
+	validator := NewValidator(
+		WithExpectedIssuer("Test Issuer"),
+		WithExpectedAudience("api"),
+		RequireVersion("2"),
+	)
+
+	token := NewToken()
+	token.AddClaim("iss", "Other Issuer")
+	token.AddClaim("ver", "2")
+
+	// Act.
+	errs := validator.ValidateAll(token)
+	validErrs := validator.ValidateAll(parsedToken(t, func(token *Token) {
+		token.AddClaim("iss", "Test Issuer")
+		token.AddClaim("ver", "2")
+		token.SetAudience("api")
+	}))
+
+	// Assert.
+	test.That(t, len(errs)).IsEqualTo(2)
+	test.That(t, errs[0]).IsEqualTo(ErrInvalidIssuer)
+	test.That(t, errs[1]).IsEqualTo(ErrInvalidAudience)
+	test.That(t, len(validErrs)).IsEqualTo(0)
+}
+
+func TestValidateExpectedType(t *testing.T) {
+	// Arrange.
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	token1 := NewToken()
+	token1.SetType("at+jwt")
+
+	err = token1.Sign(NewES256Signer(privateKey))
+	test.That(t, err).IsNil()
+
+	token1.SetType("JWT")
+
+	tokenString, err := token1.Serialize()
+	test.That(t, err).IsNil()
+
+	token2, err := Parse(tokenString)
+	test.That(t, err).IsNil()
+
+	// Act.
+	exactErr := token2.Validate(WithExpectedType("at+jwt"))
+	caseErr := token2.Validate(WithExpectedType("AT+JWT"))
+	mismatchErr := token2.Validate(WithExpectedType("JWT"))
+
+	// Assert.
+	test.That(t, token2.Header.Type).IsEqualTo("at+jwt")
+	test.That(t, exactErr).IsNil()
+	test.That(t, caseErr).IsNil()
+	test.That(t, mismatchErr).IsEqualTo(ErrInvalidType)
+	test.That(t, NewToken().Header.Type).IsEqualTo("JWT")
+}
@@ -0,0 +1,60 @@
+package jwt
+
+import "errors"
+
+// ErrUnsupportedAlgorithm is returned when there is no verifier available for
+// the algorithm in the header of a token.
+var ErrUnsupportedAlgorithm = errors.New("the token algorithm is not supported")
+
+// VerifierSet dispatches verification of tokens to a Verifier based on the
+// algorithm in their header.
+type VerifierSet struct {
+	verifiers map[Algorithm]Verifier
+}
+
+// NewVerifierSet creates a new, empty VerifierSet.
+func NewVerifierSet() *VerifierSet {
+	return &VerifierSet{
+		verifiers: map[Algorithm]Verifier{},
+	}
+}
+
+// Register registers the verifier to use for tokens with the provided
+// algorithm, replacing any verifier previously registered for it.
+func (s *VerifierSet) Register(alg Algorithm, verifier Verifier) {
+	if s.verifiers == nil {
+		s.verifiers = map[Algorithm]Verifier{}
+	}
+
+	s.verifiers[alg] = verifier
+}
+
+// RegisterKey registers a verifier for the provided algorithm created from the
+// provided key by NewVerifierFor, replacing any verifier previously registered
+// for it.  This allows custom algorithms added with RegisterAlgorithm to be
+// recognized.
+func (s *VerifierSet) RegisterKey(alg Algorithm, key interface{}) error {
+	verifier, err := NewVerifierFor(alg, key)
+	if err != nil {
+		return err
+	}
+
+	s.Register(alg, verifier)
+	return nil
+}
+
+// Verify verifies the signature on the token using the verifier registered for
+// the algorithm in its header.  Tokens with an algorithm that has no registered
+// verifier are rejected.
+func (s *VerifierSet) Verify(token *Token) error {
+	verifier, ok := s.verifiers[token.Header.Algorithm]
+	if !ok {
+		return ErrUnsupportedAlgorithm
+	}
+
+	if !token.Verify(verifier) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/ljpx/test"
+)
+
+func TestVerifierSetRoutesByAlgorithm(t *testing.T) {
+	// Arrange.
+	ecPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.That(t, err).IsNil()
+
+	verifierSet := NewVerifierSet()
+	verifierSet.Register(ES256, NewES256Verifier(&ecPrivateKey.PublicKey))
+	verifierSet.Register(RS256, NewRS256Verifier(&rsaPrivateKey.PublicKey))
+
+	esToken := NewToken()
+	esToken.AddClaim("iss", "Issuer A")
+	err = esToken.Sign(NewES256Signer(ecPrivateKey))
+	test.That(t, err).IsNil()
+
+	rsToken := NewToken()
+	rsToken.AddClaim("iss", "Issuer B")
+	err = rsToken.Sign(NewRS256Signer(rsaPrivateKey))
+	test.That(t, err).IsNil()
+
+	// Act.
+	esErr := verifierSet.Verify(esToken)
+	rsErr := verifierSet.Verify(rsToken)
+
+	// Assert.
+	test.That(t, esErr).IsNil()
+	test.That(t, rsErr).IsNil()
+}
+
+func TestVerifierSetRejectsUnregisteredAlgorithm(t *testing.T) {
+	// Arrange.
+	ecPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	verifierSet := NewVerifierSet()
+	verifierSet.Register(ES256, NewES256Verifier(&ecPrivateKey.PublicKey))
+
+	token := NewToken()
+	token.Header.Algorithm = RS256
+	token.Signature = []byte{1, 2, 3, 4}
+
+	// Act.
+	err = verifierSet.Verify(token)
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrUnsupportedAlgorithm)
+}
+
+func TestVerifierSetRejectsInvalidSignature(t *testing.T) {
+	// Arrange.
+	ecPrivateKey1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	ecPrivateKey2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err).IsNil()
+
+	verifierSet := NewVerifierSet()
+	verifierSet.Register(ES256, NewES256Verifier(&ecPrivateKey2.PublicKey))
+
+	token := NewToken()
+	err = token.Sign(NewES256Signer(ecPrivateKey1))
+	test.That(t, err).IsNil()
+
+	// Act.
+	err = verifierSet.Verify(token)
+
+	// Assert.
+	test.That(t, err).IsEqualTo(ErrInvalidSignature)
+}